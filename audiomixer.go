@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	CHANNEL_MASTER AudioChannel = iota
+	CHANNEL_MUSIC
+	CHANNEL_SFX
+	CHANNEL_UI
+	NUM_AUDIO_CHANNELS
+
+	SETTINGS_FILE = "settings.json"
+)
+
+type AudioChannel uint8
+
+func (this AudioChannel) Name() string {
+	switch this {
+	case CHANNEL_MASTER:
+		return "Lautstärke"
+	case CHANNEL_MUSIC:
+		return "Musik"
+	case CHANNEL_SFX:
+		return "Soundeffekte"
+	case CHANNEL_UI:
+		return "Menüklänge"
+	default:
+		return ""
+	}
+}
+
+type AudioMixer struct {
+	Volumes [NUM_AUDIO_CHANNELS]float32 `json:"volumes"`
+	Muted   [NUM_AUDIO_CHANNELS]bool    `json:"muted"`
+}
+
+func DefaultAudioMixer() AudioMixer {
+	return AudioMixer{
+		Volumes: [NUM_AUDIO_CHANNELS]float32{0.5, 0.5, 1.0, 1.0},
+	}
+}
+
+// Volume of a channel without the master volume, which is applied by
+// gohome.AudioMgr to every sound
+func (this *AudioMixer) Volume(channel AudioChannel) float32 {
+	if this.Muted[channel] {
+		return 0.0
+	}
+	return this.Volumes[channel]
+}
+
+func (this *AudioMixer) SetVolume(channel AudioChannel, volume float32) {
+	this.Volumes[channel] = volume
+	this.Apply()
+}
+
+func (this *AudioMixer) SetMuted(channel AudioChannel, muted bool) {
+	this.Muted[channel] = muted
+	this.Apply()
+}
+
+func (this *AudioMixer) Apply() {
+	gohome.AudioMgr.SetVolume(this.Volume(CHANNEL_MASTER))
+}
+
+func (this *AudioMixer) Play(name string, channel AudioChannel) {
+	this.PlaySound(gohome.ResourceMgr.GetSound(name), channel)
+}
+
+func (this *AudioMixer) PlaySound(sound gohome.Sound, channel AudioChannel) {
+	if sound == nil {
+		return
+	}
+	sound.SetVolume(this.Volume(channel))
+	sound.Play(false)
+}
+
+func (this *AudioMixer) Load() {
+	*this = DefaultAudioMixer()
+	data, err := ioutil.ReadFile(SETTINGS_FILE)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			gohome.ErrorMgr.Warning("Settings", SETTINGS_FILE, err.Error())
+		}
+		return
+	}
+	if err = json.Unmarshal(data, this); err != nil {
+		gohome.ErrorMgr.Warning("Settings", SETTINGS_FILE, err.Error())
+		*this = DefaultAudioMixer()
+	}
+}
+
+func (this *AudioMixer) Save() {
+	data, err := json.MarshalIndent(this, "", "\t")
+	if err == nil {
+		err = ioutil.WriteFile(SETTINGS_FILE, data, 0644)
+	}
+	if err != nil {
+		gohome.ErrorMgr.Error("Settings", SETTINGS_FILE, err.Error())
+	}
+}
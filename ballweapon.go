@@ -27,20 +27,18 @@ const (
 type BallWeaponBlock struct {
 	WeaponBlock
 	anim gohome.Tweenset
+	vel  float64
 }
 
 func (this *BallWeaponBlock) Terminate() {
 	this.WeaponBlock.Terminate()
-	gohome.UpdateMgr.RemoveObject(&this.anim)
-	this.Connector.Terminate()
+	LevelUpdates.RemoveObject(&this.anim)
 }
 
 type BallWeapon struct {
 	NilWeapon
 
-	bodies     []*box2d.B2Body
-	vels       []float64
-	ballBlocks []*BallWeaponBlock
+	rolling LoopSound
 }
 
 func (this *BallWeapon) OnAdd(p *Player) {
@@ -49,8 +47,9 @@ func (this *BallWeapon) OnAdd(p *Player) {
 
 	this.NilWeapon.OnAdd(p)
 	this.Ammo = BALL_WEAPON_AMMO
+	this.rolling = LoopSound{Name: "Rolling", Channel: CHANNEL_SFX}
 
-	gohome.UpdateMgr.AddObject(this)
+	LevelUpdates.AddObject(this)
 }
 
 func (this *BallWeapon) GetInventoryTexture() gohome.Texture {
@@ -59,26 +58,25 @@ func (this *BallWeapon) GetInventoryTexture() gohome.Texture {
 
 func (this *BallWeapon) Use(target mgl32.Vec2, energy float32) {
 	dir := target.Sub(this.Player.Transform.Position).Normalize()
-	this.bodies = append(this.bodies, this.createBall(dir, energy))
-	var vel float64
+	block := this.createBall(dir, energy)
 	if dir.X() > 0.0 {
-		vel = -float64(mgl32.DegToRad(BALL_WEAPON_ANGLE_VELOCITY))
+		block.vel = -float64(mgl32.DegToRad(BALL_WEAPON_ANGLE_VELOCITY))
 	} else {
-		vel = float64(mgl32.DegToRad(BALL_WEAPON_ANGLE_VELOCITY))
+		block.vel = float64(mgl32.DegToRad(BALL_WEAPON_ANGLE_VELOCITY))
 	}
-	this.vels = append(this.vels, vel)
 	this.Ammo--
 }
 
 func (this *BallWeapon) Update(delta_time float32) {
-	for i := 0; i < len(this.bodies); i++ {
-		b := this.bodies[i]
-		v := this.vels[i]
+	for _, block := range this.blocks {
+		b := block.GetBody()
+		v := block.(*BallWeaponBlock).vel
 		av := b.GetAngularVelocity()
 		if (v > 0.0 && av < v) || (v < 0.0 && av > v) {
 			b.SetAngularVelocity(v)
 		}
 	}
+	this.updateRollingSound()
 
 	off := [2]float32{BALL_WEAPON_OFFSET_X, BALL_WEAPON_OFFSET_Y}
 	this.Flip = this.Player.Flip
@@ -88,7 +86,29 @@ func (this *BallWeapon) Update(delta_time float32) {
 	this.Transform.Position = this.Player.Transform.Position.Add(this.Player.GetWeaponOffset()).Add(off)
 }
 
-func (this *BallWeapon) createBall(dir mgl32.Vec2, energy float32) *box2d.B2Body {
+func (this *BallWeapon) updateRollingSound() {
+	var volume float32
+	for _, block := range this.blocks {
+		b := block.GetBody()
+		if !touchingAnything(b) {
+			continue
+		}
+		speed := mgl32.Clamp(float32(math.Abs(b.GetAngularVelocity()))/mgl32.DegToRad(BALL_WEAPON_ANGLE_VELOCITY), 0.0, 1.0)
+		volume = mgl32.Max(volume, speed*attenuation(physics2d.ToPixelCoordinates(b.GetPosition())))
+	}
+	if volume < ROLLING_MIN_VOLUME {
+		volume = 0.0
+	}
+	this.rolling.SetVolume(volume)
+}
+
+func (this *BallWeapon) SetPaused(paused bool) {
+	if paused {
+		this.rolling.Stop()
+	}
+}
+
+func (this *BallWeapon) createBall(dir mgl32.Vec2, energy float32) *BallWeaponBlock {
 	pos := this.Player.Transform.Position.Add(dir.Mul(PLAYER_WIDTH * 2.0))
 
 	bodyDef := box2d.MakeB2BodyDef()
@@ -99,7 +119,7 @@ func (this *BallWeapon) createBall(dir mgl32.Vec2, energy float32) *box2d.B2Body
 	fdef.Friction = BALL_WEAPON_FRICTION
 	fdef.Density = 1.0 / (2.0 * math.Pi * physics2d.ScalarToBox2D(BALL_WEAPON_RADIUS) * physics2d.ScalarToBox2D(BALL_WEAPON_RADIUS)) * BALL_WEAPON_WEIGHT
 	fdef.Restitution = BALL_WEAPON_RESTITUTION
-	fdef.Filter.CategoryBits = WEAPON_CATEGORY | BALL_CATEGORY
+	fdef.Filter = CollisionFilter(BALL_CATEGORY)
 	shape := box2d.MakeB2CircleShape()
 	shape.SetRadius(physics2d.ScalarToBox2D(BALL_WEAPON_RADIUS))
 	fdef.Shape = &shape
@@ -109,19 +129,12 @@ func (this *BallWeapon) createBall(dir mgl32.Vec2, energy float32) *box2d.B2Body
 	body.SetLinearVelocity(physics2d.ToBox2DDirection(dir.Mul(BALL_WEAPON_VELOCITY * energy)))
 	body.SetLinearVelocity(box2d.B2Vec2Add(this.Player.body.GetLinearVelocity(), body.GetLinearVelocity()))
 
-	var spr gohome.Sprite2D
-	var con physics2d.PhysicsConnector2D
-
-	spr.Init("BallWeaponBlock")
+	block := &BallWeaponBlock{}
+	block.Init(BLOCK_BALL, this, "BallWeaponBlock", body)
+	spr := &block.Sprite
 	spr.TextureRegion.Max[0] = float32(spr.Texture.GetWidth()) / 7.0
 	spr.Transform.Size[0] = spr.TextureRegion.Max[0]
-	con.Init(spr.Transform, body, &PhysicsMgr)
-
-	gohome.RenderMgr.AddObject(&spr)
 
-	var block BallWeaponBlock
-	block.Sprite = &spr
-	block.Connector = &con
 	block.anim = gohome.SpriteAnimation2D(spr.Texture, 7, 1, BALL_WEAPON_FRAME_TIME)
 	block.anim.Tweens = append(block.anim.Tweens, &gohome.TweenRegion2D{
 		TweenType: gohome.TWEEN_TYPE_AFTER_PREVIOUS,
@@ -136,27 +149,22 @@ func (this *BallWeapon) createBall(dir mgl32.Vec2, energy float32) *box2d.B2Body
 		TweenType: gohome.TWEEN_TYPE_AFTER_PREVIOUS,
 	})
 	block.anim.Loop = true
-	block.anim.SetParent(&spr)
+	block.anim.SetParent(spr)
 	block.anim.Start()
-	gohome.UpdateMgr.AddObject(&block.anim)
-	this.ballBlocks = append(this.ballBlocks, &block)
+	LevelUpdates.AddObject(&block.anim)
+	this.addBlock(block)
 
-	body.SetUserData(this.ballBlocks[len(this.ballBlocks)-1])
-
-	con.Update()
-
-	return body
+	return block
 }
 
 func (this *BallWeapon) OnDie() {
-	gohome.UpdateMgr.RemoveObject(this)
+	this.rolling.Stop()
+	LevelUpdates.RemoveObject(this)
 	gohome.RenderMgr.RemoveObject(&this.NilWeapon)
 }
 
 func (this *BallWeapon) Terminate() {
+	this.rolling.Stop()
 	this.NilWeapon.Terminate()
-	gohome.UpdateMgr.RemoveObject(this)
-	for _, block := range this.ballBlocks {
-		block.Terminate()
-	}
+	LevelUpdates.RemoveObject(this)
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+)
+
+const (
+	BLOCK_DEFAULT BlockKind = iota
+	BLOCK_FREEZE
+	BLOCK_BALL
+	BLOCK_MOVE
+)
+
+type BlockKind uint8
+
+type Block interface {
+	GetKind() BlockKind
+	GetWeapon() Weapon
+	GetSprite() *gohome.Sprite2D
+	GetBody() *box2d.B2Body
+	Terminate()
+
+	base() *WeaponBlock
+}
+
+func GetBlock(body *box2d.B2Body) Block {
+	if body == nil {
+		return nil
+	}
+	block, _ := body.GetUserData().(Block)
+	return block
+}
+
+type WeaponBlock struct {
+	Kind      BlockKind
+	Weapon    Weapon
+	Sprite    gohome.Sprite2D
+	Body      *box2d.B2Body
+	Connector Connector
+
+	deleting   bool
+	terminated bool
+}
+
+func (this *WeaponBlock) Init(kind BlockKind, weapon Weapon, texName string, body *box2d.B2Body) {
+	this.Kind = kind
+	this.Weapon = weapon
+	this.Body = body
+	this.Sprite.Init(texName)
+	this.Sprite.Depth = MAP_DEPTH
+}
+
+func (this *WeaponBlock) GetKind() BlockKind {
+	return this.Kind
+}
+
+func (this *WeaponBlock) GetWeapon() Weapon {
+	return this.Weapon
+}
+
+func (this *WeaponBlock) GetSprite() *gohome.Sprite2D {
+	return &this.Sprite
+}
+
+func (this *WeaponBlock) GetBody() *box2d.B2Body {
+	return this.Body
+}
+
+func (this *WeaponBlock) base() *WeaponBlock {
+	return this
+}
+
+func (this *WeaponBlock) Terminate() {
+	this.Weapon.removeBlock(this)
+	if this.terminated {
+		return
+	}
+	this.terminated = true
+	gohome.RenderMgr.RemoveObject(&this.Sprite)
+	this.Connector.Terminate()
+	this.Body.SetUserData(nil)
+}
+
+func (this *NilWeapon) addBlock(block Block) {
+	b := block.base()
+	b.Connector.Init(b.Sprite.Transform, b.Body)
+	b.Body.SetUserData(block)
+	gohome.RenderMgr.AddObject(&b.Sprite)
+	this.blocks = append(this.blocks, block)
+	Entities.Add(block)
+}
+
+func (this *NilWeapon) removeBlock(block *WeaponBlock) {
+	for i := 0; i < len(this.blocks); i++ {
+		if this.blocks[i].base() == block {
+			Entities.Remove(this.blocks[i])
+			this.blocks = append(this.blocks[:i], this.blocks[i+1:]...)
+			return
+		}
+	}
+}
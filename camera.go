@@ -0,0 +1,171 @@
+package main
+
+import (
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"github.com/PucklaMotzer09/tmx"
+	"math"
+)
+
+const (
+	CAMERA_MODE_BOX       uint8 = 0
+	CAMERA_MODE_FOLLOW    uint8 = 1
+	CAMERA_MODE_LOOKAHEAD uint8 = 2
+
+	CAMERA_DEADZONE_WIDTH  float32 = CAMERA_BOX_WIDTH / 6.0
+	CAMERA_DEADZONE_HEIGHT float32 = CAMERA_BOX_HEIGHT / 4.0
+	CAMERA_LOOK_AHEAD      float32 = CAMERA_BOX_WIDTH / 4.0
+)
+
+func cameraMode(name string) uint8 {
+	switch name {
+	case "follow":
+		return CAMERA_MODE_FOLLOW
+	case "lookahead":
+		return CAMERA_MODE_LOOKAHEAD
+	default:
+		return CAMERA_MODE_BOX
+	}
+}
+
+type CameraZone struct {
+	Position mgl32.Vec2
+	Size     mgl32.Vec2
+	Mode     uint8
+	Clamp    bool
+}
+
+func (this *CameraZone) Contains(p mgl32.Vec2) bool {
+	return p.X() >= this.Position.X() && p.X() <= this.Position.X()+this.Size.X() &&
+		p.Y() >= this.Position.Y() && p.Y() <= this.Position.Y()+this.Size.Y()
+}
+
+type CameraController struct {
+	Player    *Player
+	Mode      uint8
+	Bounds    mgl32.Vec2
+	DeadZone  mgl32.Vec2
+	LookAhead float32
+	Zones     []*CameraZone
+
+	center mgl32.Vec2
+	shake  mgl32.Vec2
+	paused bool
+}
+
+func (this *CameraController) Init(player *Player, bounds mgl32.Vec2, props *tmx.Properties) {
+	this.Player = player
+	this.Bounds = bounds
+	this.Mode = cameraMode(getPropertyString(props, "camera", "box"))
+	this.DeadZone = [2]float32{
+		getPropertyFloat(props, "camera_deadzone_width", CAMERA_DEADZONE_WIDTH),
+		getPropertyFloat(props, "camera_deadzone_height", CAMERA_DEADZONE_HEIGHT),
+	}
+	this.LookAhead = getPropertyFloat(props, "camera_lookahead", CAMERA_LOOK_AHEAD)
+	this.center = player.Transform.Position
+	this.shake = [2]float32{0.0, 0.0}
+	CameraFX.Init()
+
+	gohome.UpdateMgr.AddObject(this)
+	Entities.Add(this)
+}
+
+func (this *CameraController) AddZone(o tmx.Object) {
+	this.Zones = append(this.Zones, &CameraZone{
+		Position: [2]float32{float32(o.X), float32(o.Y)},
+		Size:     [2]float32{float32(o.Width), float32(o.Height)},
+		Mode:     cameraMode(getPropertyString(o.Properties, "mode", "box")),
+		Clamp:    getPropertyBool(o.Properties, "clamp", false),
+	})
+}
+
+func (this *CameraController) viewSize() mgl32.Vec2 {
+	return [2]float32{float32(GAME_WIDTH) / Camera.Zoom, float32(GAME_HEIGHT) / Camera.Zoom}
+}
+
+func (this *CameraController) boxTarget(pos mgl32.Vec2) mgl32.Vec2 {
+	boxX := float32(math.Floor(float64(pos.X() / CAMERA_BOX_WIDTH)))
+	boxY := float32(math.Floor(float64(pos.Y() / CAMERA_BOX_HEIGHT)))
+	return [2]float32{
+		boxX*CAMERA_BOX_WIDTH + CAMERA_OFFSET[0],
+		boxY*CAMERA_BOX_HEIGHT + CAMERA_OFFSET[1],
+	}
+}
+
+func (this *CameraController) followTarget(pos mgl32.Vec2) mgl32.Vec2 {
+	for i := 0; i < 2; i++ {
+		half := this.DeadZone[i] / 2.0
+		if pos[i] > this.center[i]+half {
+			this.center[i] = pos[i] - half
+		} else if pos[i] < this.center[i]-half {
+			this.center[i] = pos[i] + half
+		}
+	}
+	return this.center.Sub(this.viewSize().Mul(0.5))
+}
+
+func (this *CameraController) clamp(target, min, max mgl32.Vec2) mgl32.Vec2 {
+	view := this.viewSize()
+	for i := 0; i < 2; i++ {
+		upper := max[i] - view[i]
+		if upper < min[i] {
+			upper = min[i]
+		}
+		target[i] = mgl32.Clamp(target[i], min[i], upper)
+	}
+	return target
+}
+
+func (this *CameraController) Update(delta_time float32) {
+	if this.paused {
+		return
+	}
+
+	CameraFX.Update(delta_time)
+	Camera.Zoom = CameraFX.Zoom
+	position := Camera.Position.Sub(this.shake)
+	if !this.Player.Died() {
+		position = this.follow(position, delta_time)
+		if this.Player.AimDirection().Len() >= CAMERA_AIM_ZOOM_ENERGY {
+			CameraFX.TargetZoom = CAMERA_AIM_ZOOM
+		} else {
+			CameraFX.TargetZoom = ZOOM
+		}
+	}
+	this.shake = CameraFX.Offset()
+	Camera.Position = position.Add(this.shake)
+}
+
+func (this *CameraController) follow(position mgl32.Vec2, delta_time float32) mgl32.Vec2 {
+	pos := this.Player.Transform.Position
+	mode := this.Mode
+	min, max := mgl32.Vec2{0.0, 0.0}, this.Bounds
+	for _, z := range this.Zones {
+		if z.Contains(pos) {
+			mode = z.Mode
+			if z.Clamp {
+				min, max = z.Position, z.Position.Add(z.Size)
+			}
+			break
+		}
+	}
+
+	var target mgl32.Vec2
+	switch mode {
+	case CAMERA_MODE_FOLLOW:
+		target = this.followTarget(pos)
+	case CAMERA_MODE_LOOKAHEAD:
+		target = this.followTarget(pos).Add(this.Player.AimDirection().Mul(this.LookAhead))
+	default:
+		target = this.boxTarget(pos)
+		this.center = pos
+	}
+	target = this.clamp(target, min, max)
+
+	return position.Add(target.Sub(position).Mul((1.0 / CAMERA_SPEED) * delta_time))
+}
+
+func (this *CameraController) Terminate() {
+	gohome.UpdateMgr.RemoveObject(this)
+	Entities.Remove(this)
+}
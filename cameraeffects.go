@@ -0,0 +1,104 @@
+package main
+
+import (
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"math"
+)
+
+const (
+	CAMERA_SHAKE_OFFSET float32 = 6.0
+	CAMERA_SHAKE_SPEED  float32 = 30.0
+	CAMERA_TRAUMA_DECAY float32 = 1.5
+
+	CAMERA_ZOOM_SPEED      float32 = 3.0
+	CAMERA_AIM_ZOOM        float32 = ZOOM * 0.75
+	CAMERA_AIM_ZOOM_ENERGY float32 = 0.8
+
+	CAMERA_IMPACT_IMPULSE float64 = 2.0
+	CAMERA_IMPACT_TRAUMA  float32 = 0.3
+
+	CAMERA_KILL_TRAUMA      float32 = 0.4
+	CAMERA_DEATH_TRAUMA     float32 = 0.7
+	CAMERA_KILL_SLOW_TIME   float32 = 0.3
+	CAMERA_KILL_SLOW_FACTOR float32 = 0.3
+)
+
+var CAMERA_EFFECTS = true
+
+type CameraEffects struct {
+	Trauma     float32
+	Zoom       float32
+	TargetZoom float32
+
+	BaseTimeScale float32
+
+	time     float32
+	slowTime float32
+	offset   mgl32.Vec2
+}
+
+func (this *CameraEffects) Init() {
+	this.Trauma = 0.0
+	this.Zoom = ZOOM
+	this.TargetZoom = ZOOM
+	this.time = 0.0
+	this.slowTime = 0.0
+	this.offset = [2]float32{0.0, 0.0}
+	this.BaseTimeScale = 1.0
+	LevelUpdates.TimeScale = this.BaseTimeScale
+}
+
+func (this *CameraEffects) SetBaseTimeScale(scale float32) {
+	this.BaseTimeScale = scale
+	if this.slowTime <= 0.0 {
+		LevelUpdates.TimeScale = scale
+	}
+}
+
+func (this *CameraEffects) AddTrauma(amount float32) {
+	if !CAMERA_EFFECTS {
+		return
+	}
+	this.Trauma = mgl32.Clamp(this.Trauma+amount, 0.0, 1.0)
+}
+
+func (this *CameraEffects) SlowMotion(duration, factor float32) {
+	if !CAMERA_EFFECTS {
+		return
+	}
+	this.slowTime = duration
+	LevelUpdates.TimeScale = factor * this.BaseTimeScale
+}
+
+func (this *CameraEffects) noise(seed float32) float32 {
+	t := float64(this.time*CAMERA_SHAKE_SPEED + seed)
+	return float32(math.Sin(t) * math.Cos(t*1.7+float64(seed)))
+}
+
+func (this *CameraEffects) Update(delta_time float32) {
+	this.time += delta_time
+
+	if this.slowTime > 0.0 {
+		this.slowTime -= delta_time
+		if this.slowTime <= 0.0 {
+			LevelUpdates.TimeScale = this.BaseTimeScale
+		}
+	}
+
+	if !CAMERA_EFFECTS {
+		this.Trauma = 0.0
+		this.TargetZoom = ZOOM
+	}
+	this.Zoom += (this.TargetZoom - this.Zoom) * mgl32.Clamp(CAMERA_ZOOM_SPEED*delta_time, 0.0, 1.0)
+
+	shake := this.Trauma * this.Trauma
+	this.offset = [2]float32{
+		CAMERA_SHAKE_OFFSET * shake * this.noise(0.0),
+		CAMERA_SHAKE_OFFSET * shake * this.noise(100.0),
+	}
+	this.Trauma = mgl32.Clamp(this.Trauma-CAMERA_TRAUMA_DECAY*delta_time, 0.0, 1.0)
+}
+
+func (this *CameraEffects) Offset() mgl32.Vec2 {
+	return this.offset
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"github.com/PucklaMotzer09/tmx"
+	"math"
+)
+
+const (
+	COLLISION_LAYER   string = "Collision"
+	COLLISION_TILESET string = "tileset"
+
+	ONE_WAY_TOLERANCE float32 = 2.0
+	ONE_WAY_DROP_TIME float32 = 0.25
+)
+
+type OneWay struct {
+}
+
+func isOneWay(f *box2d.B2Fixture) bool {
+	_, ok := f.GetUserData().(*OneWay)
+	return ok
+}
+
+func fixtureBounds(f *box2d.B2Fixture) (top, bottom float32) {
+	var aabb box2d.B2AABB
+	f.GetShape().ComputeAABB(&aabb, f.GetBody().GetTransform(), 0)
+	lower := physics2d.ToPixelCoordinates(aabb.LowerBound)
+	upper := physics2d.ToPixelCoordinates(aabb.UpperBound)
+	return mgl32.Min(lower.Y(), upper.Y()), mgl32.Max(lower.Y(), upper.Y())
+}
+
+func landsOnOneWay(platform, other *box2d.B2Fixture) bool {
+	if p, ok := other.GetBody().GetUserData().(*Player); ok && p.Dropping() {
+		return false
+	}
+	top, _ := fixtureBounds(platform)
+	_, bottom := fixtureBounds(other)
+	return bottom <= top+ONE_WAY_TOLERANCE
+}
+
+// Impacts are only reported for contacts which hit with some speed, a body
+// resting on the ground gets a PostSolve every step too
+type LevelContactListener struct {
+	impacts map[box2d.B2ContactInterface]bool
+}
+
+func (this *LevelContactListener) BeginContact(contact box2d.B2ContactInterface) {
+	Contacts.Dispatch(CONTACT_BEGIN, contact)
+}
+
+func (this *LevelContactListener) EndContact(contact box2d.B2ContactInterface) {
+	delete(this.impacts, contact)
+	Contacts.Dispatch(CONTACT_END, contact)
+}
+
+func (this *LevelContactListener) PreSolve(contact box2d.B2ContactInterface, oldManifold box2d.B2Manifold) {
+	fa := contact.GetFixtureA()
+	fb := contact.GetFixtureB()
+	if isOneWay(fa) && !landsOnOneWay(fa, fb) {
+		contact.SetEnabled(false)
+	} else if isOneWay(fb) && !landsOnOneWay(fb, fa) {
+		contact.SetEnabled(false)
+	}
+	if contact.IsEnabled() && approachVelocity(contact) >= IMPACT_MIN_APPROACH_VELOCITY {
+		if this.impacts == nil {
+			this.impacts = make(map[box2d.B2ContactInterface]bool)
+		}
+		this.impacts[contact] = true
+	} else {
+		delete(this.impacts, contact)
+	}
+	Contacts.Dispatch(CONTACT_PRE_SOLVE, contact)
+}
+
+func (this *LevelContactListener) PostSolve(contact box2d.B2ContactInterface, impulse *box2d.B2ContactImpulse) {
+	if !this.impacts[contact] {
+		return
+	}
+	delete(this.impacts, contact)
+	var max float64
+	for i := 0; i < impulse.Count; i++ {
+		max = math.Max(max, impulse.NormalImpulses[i])
+	}
+	playImpactSound(contact, max)
+	if max >= CAMERA_IMPACT_IMPULSE {
+		CameraFX.AddTrauma(CAMERA_IMPACT_TRAUMA * float32(max/CAMERA_IMPACT_IMPULSE-1.0))
+	}
+}
+
+type collisionCell struct {
+	Shape  TileShape
+	OneWay bool
+	Solid  bool
+	used   bool
+}
+
+func (this *collisionCell) matches(other *collisionCell) bool {
+	return this.Solid && !this.used && this.OneWay == other.OneWay && this.Shape == other.Shape
+}
+
+func addCollisionBox(body *box2d.B2Body, pos, size mgl32.Vec2, oneway bool) {
+	shape := box2d.MakeB2PolygonShape()
+	shape.SetAsBoxFromCenterAndAngle(physics2d.ScalarToBox2D(size[0]/2.0), physics2d.ScalarToBox2D(size[1]/2.0), physics2d.ToBox2DCoordinates(pos.Add(size.Mul(0.5))), 0.0)
+
+	fdef := box2d.MakeB2FixtureDef()
+	fdef.Friction = GROUND_FRICTION
+	fdef.Filter = CollisionFilter(GROUND_CATEGORY)
+	fdef.Shape = &shape
+	f := body.CreateFixtureFromDef(&fdef)
+	if oneway {
+		f.SetUserData(&OneWay{})
+	}
+}
+
+func (this *LevelScene) initCollision() {
+	if getPropertyString(this.Map.Properties, "collision", COLLISION_LAYER) == COLLISION_TILESET {
+		this.initTileCollision()
+		return
+	}
+
+	var objs []tmx.Object
+	for _, l := range this.Map.Layers {
+		if l.Name == COLLISION_LAYER {
+			objs = l.Objects
+		}
+	}
+
+	groundBodies := PhysicsMgr.LayerToCollision(&this.Map, COLLISION_LAYER)
+	if len(groundBodies) != len(objs) {
+		gohome.ErrorMgr.Warning("Level", "Collision", "Couldn't match collision bodies to objects, one-way objects are solid")
+		objs = nil
+	}
+	for i := 0; i < len(groundBodies); i++ {
+		b := groundBodies[i]
+		if b == nil {
+			continue
+		}
+		oneway := objs != nil && getPropertyBool(objs[i].Properties, "oneway", false)
+		for f := b.GetFixtureList(); f != nil; f = f.GetNext() {
+			SetCollisionCategory(f, GROUND_CATEGORY)
+			f.SetFriction(GROUND_FRICTION)
+			if oneway {
+				f.SetUserData(&OneWay{})
+			}
+		}
+	}
+}
+
+// Only one tile layer is solid so decoration layers stay passable, it is
+// chosen with the collision_layer map property and defaults to the first one
+func (this *LevelScene) collisionTileLayer() string {
+	name := getPropertyString(this.Map.Properties, "collision_layer", "")
+	for _, l := range this.Map.Layers {
+		if l.Data != nil && (name == "" || l.Name == name) {
+			return l.Name
+		}
+	}
+	return ""
+}
+
+func (this *LevelScene) initTileCollision() {
+	if this.tilesets == nil {
+		gohome.ErrorMgr.Error("Level", "Collision", "No tilesets loaded for "+this.MapFile)
+		return
+	}
+
+	layer := this.collisionTileLayer()
+	if layer == "" {
+		gohome.ErrorMgr.Error("Level", "Collision", "No collision tile layer in "+this.MapFile)
+		return
+	}
+
+	w, h := this.Map.Width, this.Map.Height
+	tw, th := float32(this.Map.TileWidth), float32(this.Map.TileHeight)
+	cells := make([]collisionCell, w*h)
+
+	bdef := box2d.MakeB2BodyDef()
+	bdef.Type = box2d.B2BodyType.B2_staticBody
+	body := PhysicsMgr.World.CreateBody(&bdef)
+
+	this.eachTile(layer, func(col, row int, tile *TilesetTile) {
+		if len(tile.Shapes) == 0 {
+			return
+		}
+		oneway := tile.Properties["oneway"] == "true"
+		s := tile.Shapes[0]
+		if len(tile.Shapes) == 1 && s.Position.X() == 0.0 && s.Size.X() == tw {
+			cells[row*w+col] = collisionCell{Shape: s, OneWay: oneway, Solid: true}
+			return
+		}
+		origin := mgl32.Vec2{float32(col) * tw, float32(row) * th}
+		for _, s := range tile.Shapes {
+			addCollisionBox(body, origin.Add(s.Position), s.Size, oneway)
+		}
+	})
+
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			c := &cells[row*w+col]
+			if !c.Solid || c.used {
+				continue
+			}
+			width := 1
+			for col+width < w && cells[row*w+col+width].matches(c) {
+				width++
+			}
+			height := 1
+			if !c.OneWay && c.Shape.Position.Y() == 0.0 && c.Shape.Size.Y() == th {
+				for ; row+height < h; height++ {
+					full := true
+					for x := col; x < col+width && full; x++ {
+						full = cells[(row+height)*w+x].matches(c)
+					}
+					if !full {
+						break
+					}
+				}
+			}
+			for y := row; y < row+height; y++ {
+				for x := col; x < col+width; x++ {
+					cells[y*w+x].used = true
+				}
+			}
+			pos := mgl32.Vec2{float32(col) * tw, float32(row)*th + c.Shape.Position.Y()}
+			size := mgl32.Vec2{float32(width) * tw, float32(height-1)*th + c.Shape.Size.Y()}
+			addCollisionBox(body, pos, size, c.OneWay)
+		}
+	}
+}
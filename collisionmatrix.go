@@ -0,0 +1,137 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"strings"
+)
+
+const (
+	COLLISION_LEGEND_FONT_SIZE int     = 16
+	COLLISION_LEGEND_OFFSET_Y  float32 = 300.0
+)
+
+const (
+	PLAYER_CATEGORY uint16 = 1 << iota
+	PLAYER_FEET_CATEGORY
+	PLAYER_FEET_SENSOR_CATEGORY
+	PLAYER_WALL_LEFT_SENSOR_CATEGORY
+	PLAYER_WALL_RIGHT_SENSOR_CATEGORY
+	GROUND_CATEGORY
+	WEAPON_CATEGORY
+	BALL_CATEGORY
+	ENEMY_CATEGORY
+	ENEMY_SMALL_LEFT_SENSOR_CATEGORY
+	ENEMY_SMALL_RIGHT_SENSOR_CATEGORY
+	ENEMY_BIG_LEFT_SENSOR_CATEGORY
+	ENEMY_BIG_RIGHT_SENSOR_CATEGORY
+	SPIKE_CATEGORY
+	SWITCH_CATEGORY
+)
+
+const (
+	PLAYER_CATEGORIES       uint16 = PLAYER_CATEGORY | PLAYER_FEET_CATEGORY | PLAYER_FEET_SENSOR_CATEGORY
+	WEAPON_CATEGORIES       uint16 = WEAPON_CATEGORY | BALL_CATEGORY
+	ENEMY_SENSOR_CATEGORIES uint16 = ENEMY_SMALL_LEFT_SENSOR_CATEGORY | ENEMY_SMALL_RIGHT_SENSOR_CATEGORY | ENEMY_BIG_LEFT_SENSOR_CATEGORY | ENEMY_BIG_RIGHT_SENSOR_CATEGORY
+	ALL_CATEGORIES          uint16 = 0xffff
+)
+
+type CollisionCategory struct {
+	Category uint16
+	Name     string
+	Mask     uint16
+}
+
+// Two fixtures collide (or a sensor senses the other fixture) only if
+// both of their categories list each other in their mask
+var CollisionMatrix = [...]CollisionCategory{
+	{PLAYER_CATEGORY, "Player", ALL_CATEGORIES},
+	{PLAYER_FEET_CATEGORY, "PlayerFeet", ALL_CATEGORIES},
+	{PLAYER_FEET_SENSOR_CATEGORY, "PlayerFeetSensor", ALL_CATEGORIES},
+	{PLAYER_WALL_LEFT_SENSOR_CATEGORY, "PlayerWallLeft", GROUND_CATEGORY},
+	{PLAYER_WALL_RIGHT_SENSOR_CATEGORY, "PlayerWallRight", GROUND_CATEGORY},
+	{GROUND_CATEGORY, "Ground", ALL_CATEGORIES},
+	{WEAPON_CATEGORY, "Weapon", ALL_CATEGORIES},
+	{BALL_CATEGORY, "Ball", ALL_CATEGORIES},
+	{ENEMY_CATEGORY, "Enemy", ALL_CATEGORIES},
+	{ENEMY_SMALL_LEFT_SENSOR_CATEGORY, "EnemySmallLeft", GROUND_CATEGORY | WEAPON_CATEGORIES},
+	{ENEMY_SMALL_RIGHT_SENSOR_CATEGORY, "EnemySmallRight", GROUND_CATEGORY | WEAPON_CATEGORIES},
+	{ENEMY_BIG_LEFT_SENSOR_CATEGORY, "EnemyBigLeft", GROUND_CATEGORY | WEAPON_CATEGORIES | ENEMY_CATEGORY},
+	{ENEMY_BIG_RIGHT_SENSOR_CATEGORY, "EnemyBigRight", GROUND_CATEGORY | WEAPON_CATEGORIES | ENEMY_CATEGORY},
+	{SPIKE_CATEGORY, "Spike", ALL_CATEGORIES},
+	{SWITCH_CATEGORY, "Switch", ALL_CATEGORIES},
+}
+
+func collisionCategory(category uint16) *CollisionCategory {
+	for i := 0; i < len(CollisionMatrix); i++ {
+		if CollisionMatrix[i].Category == category {
+			return &CollisionMatrix[i]
+		}
+	}
+	return nil
+}
+
+func CollisionMask(category uint16) uint16 {
+	if c := collisionCategory(category); c != nil {
+		return c.Mask
+	}
+	gohome.ErrorMgr.Warning("Collision", CategoryNames(category), "Category is not part of the collision matrix")
+	return ALL_CATEGORIES
+}
+
+func CollisionFilter(category uint16) box2d.B2Filter {
+	filter := box2d.MakeB2Filter()
+	filter.CategoryBits = category
+	filter.MaskBits = CollisionMask(category)
+	return filter
+}
+
+func SetCollisionCategory(f *box2d.B2Fixture, category uint16) {
+	filter := f.GetFilterData()
+	filter.CategoryBits = category
+	filter.MaskBits = CollisionMask(category)
+	f.SetFilterData(filter)
+}
+
+func Collides(a, b uint16) bool {
+	return CollisionMask(a)&b != 0 && CollisionMask(b)&a != 0
+}
+
+func CategoryNames(bits uint16) string {
+	if bits == ALL_CATEGORIES {
+		return "All"
+	}
+	var names []string
+	for _, c := range CollisionMatrix {
+		if bits&c.Category != 0 {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, ", ")
+}
+
+type CollisionLegend struct {
+	gohome.Text2D
+}
+
+func (this *CollisionLegend) Init() {
+	var lines []string
+	for _, c := range CollisionMatrix {
+		lines = append(lines, c.Name+": "+CategoryNames(c.Mask))
+	}
+	this.Text2D.Init(gohome.ButtonFont, COLLISION_LEGEND_FONT_SIZE, strings.Join(lines, "\n"))
+	this.Text2D.NotRelativeToCamera = 0
+	this.Transform.Position = [2]float32{0.0, COLLISION_LEGEND_OFFSET_Y}
+	gohome.RenderMgr.AddObject(this)
+
+	this.Depth = 255
+	this.Visible = false
+}
+
+func (this *CollisionLegend) Terminate() {
+	this.Text2D.Terminate()
+	gohome.RenderMgr.RemoveObject(this)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+)
+
+const (
+	CONTACT_BEGIN ContactEventType = iota
+	CONTACT_END
+	CONTACT_PRE_SOLVE
+)
+
+type ContactEventType uint8
+
+type ContactEvent struct {
+	Type    ContactEventType
+	Contact box2d.B2ContactInterface
+	Fixture *box2d.B2Fixture
+	Other   *box2d.B2Fixture
+}
+
+type ContactHandler func(event *ContactEvent)
+
+type ContactFilter struct {
+	Body          *box2d.B2Body
+	Category      uint16
+	Other         uint16
+	IgnoreSensors bool
+}
+
+func (this *ContactFilter) matches(fixture, other *box2d.B2Fixture) bool {
+	if this.Body != nil && fixture.GetBody() != this.Body {
+		return false
+	}
+	if this.IgnoreSensors && other.IsSensor() {
+		return false
+	}
+	if fixture.GetFilterData().CategoryBits&this.Category != this.Category {
+		return false
+	}
+	return this.Other == 0 || other.GetFilterData().CategoryBits&this.Other != 0
+}
+
+type ContactSubscription struct {
+	ContactFilter
+	Begin    ContactHandler
+	End      ContactHandler
+	PreSolve ContactHandler
+}
+
+func (this *ContactSubscription) handler(eventType ContactEventType) ContactHandler {
+	switch eventType {
+	case CONTACT_BEGIN:
+		return this.Begin
+	case CONTACT_END:
+		return this.End
+	case CONTACT_PRE_SOLVE:
+		return this.PreSolve
+	default:
+		return nil
+	}
+}
+
+type ContactBus struct {
+	subscriptions []*ContactSubscription
+}
+
+func (this *ContactBus) Init() {
+	this.subscriptions = nil
+}
+
+func (this *ContactBus) Subscribe(sub *ContactSubscription) {
+	this.subscriptions = append(this.subscriptions, sub)
+}
+
+func (this *ContactBus) Unsubscribe(sub *ContactSubscription) {
+	for i := 0; i < len(this.subscriptions); i++ {
+		if this.subscriptions[i] == sub {
+			this.subscriptions = append(this.subscriptions[:i], this.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+func (this *ContactBus) Dispatch(eventType ContactEventType, contact box2d.B2ContactInterface) {
+	fa, fb := contact.GetFixtureA(), contact.GetFixtureB()
+	for _, sub := range this.subscriptions {
+		h := sub.handler(eventType)
+		if h == nil {
+			continue
+		}
+		if sub.matches(fa, fb) {
+			h(&ContactEvent{eventType, contact, fa, fb})
+		}
+		if sub.matches(fb, fa) {
+			h(&ContactEvent{eventType, contact, fb, fa})
+		}
+	}
+}
+
+func (this *ContactBus) Terminate() {
+	this.subscriptions = nil
+}
+
+type ContactCounter struct {
+	Count int
+
+	sub ContactSubscription
+}
+
+func (this *ContactCounter) Init(filter ContactFilter) {
+	this.Count = 0
+	this.sub = ContactSubscription{
+		ContactFilter: filter,
+		Begin:         func(event *ContactEvent) { this.Count++ },
+		End:           func(event *ContactEvent) { this.Count-- },
+	}
+	Contacts.Subscribe(&this.sub)
+}
+
+func (this *ContactCounter) Touching() bool {
+	return this.Count > 0
+}
+
+func (this *ContactCounter) Terminate() {
+	Contacts.Unsubscribe(&this.sub)
+	this.Count = 0
+}
+
+type ContactTracker struct {
+	Events []ContactEvent
+
+	sub ContactSubscription
+}
+
+func (this *ContactTracker) Init(filter ContactFilter) {
+	this.Events = nil
+	this.sub = ContactSubscription{
+		ContactFilter: filter,
+		Begin:         this.begin,
+		End:           this.end,
+	}
+	Contacts.Subscribe(&this.sub)
+}
+
+func (this *ContactTracker) begin(event *ContactEvent) {
+	this.Events = append(this.Events, *event)
+}
+
+func (this *ContactTracker) end(event *ContactEvent) {
+	for i := 0; i < len(this.Events); i++ {
+		if this.Events[i].Contact == event.Contact && this.Events[i].Fixture == event.Fixture {
+			this.Events = append(this.Events[:i], this.Events[i+1:]...)
+			return
+		}
+	}
+}
+
+func (this *ContactTracker) Terminate() {
+	Contacts.Unsubscribe(&this.sub)
+	this.Events = nil
+}
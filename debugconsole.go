@@ -0,0 +1,413 @@
+//go:build debug
+// +build debug
+
+package main
+
+import (
+	"fmt"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	CONSOLE_KEY       = gohome.KeyF1
+	CONSOLE_FONT_SIZE = 16
+	CONSOLE_LINES     = 12
+	CONSOLE_PROMPT    = "> "
+)
+
+type consoleCommand struct {
+	Usage string
+	Run   func(scn *LevelScene, args []string) string
+}
+
+var consoleCommands map[string]consoleCommand
+
+var playerTunables = map[string]interface{}{
+	"PLAYER_RESTITUITION":         PLAYER_RESTITUITION,
+	"PLAYER_FRICTION":             PLAYER_FRICTION,
+	"PLAYER_HEIGHT":               PLAYER_HEIGHT,
+	"PLAYER_WIDTH":                PLAYER_WIDTH,
+	"PLAYER_VELOCITY":             PLAYER_VELOCITY,
+	"PLAYER_JUMP_FORCE":           PLAYER_JUMP_FORCE,
+	"PLAYER_DAMPING":              PLAYER_DAMPING,
+	"PLAYER_MAX_VELOCITY":         PLAYER_MAX_VELOCITY,
+	"PLAYER_WEIGHT":               PLAYER_WEIGHT,
+	"PLAYER_FEET_SENSOR_WIDTH":    PLAYER_FEET_SENSOR_WIDTH,
+	"PLAYER_FEET_SENSOR_HEIGHT":   PLAYER_FEET_SENSOR_HEIGHT,
+	"PLAYER_FEET_SENSOR_OFFSET_X": PLAYER_FEET_SENSOR_OFFSET_X,
+	"PLAYER_FEET_SENSOR_OFFSET_Y": PLAYER_FEET_SENSOR_OFFSET_Y,
+	"PLAYER_WALL_SENSOR_WIDTH":    PLAYER_WALL_SENSOR_WIDTH,
+	"PLAYER_WALL_SENSOR_HEIGHT":   PLAYER_WALL_SENSOR_HEIGHT,
+	"PLAYER_WALL_SENSOR_OFFSET_X": PLAYER_WALL_SENSOR_OFFSET_X,
+	"PLAYER_WALL_SENSOR_OFFSET_Y": PLAYER_WALL_SENSOR_OFFSET_Y,
+	"PLAYER_WALL_SLIDE_VELOCITY":  PLAYER_WALL_SLIDE_VELOCITY,
+	"PLAYER_WALL_JUMP_FORCE_X":    PLAYER_WALL_JUMP_FORCE_X,
+	"PLAYER_WALL_JUMP_FORCE_Y":    PLAYER_WALL_JUMP_FORCE_Y,
+	"PLAYER_WALL_JUMP_LOCK_TIME":  PLAYER_WALL_JUMP_LOCK_TIME,
+	"PLAYER_MAX_GROUND_SLOPE":     PLAYER_MAX_GROUND_SLOPE,
+	"PLAYER_ENEMY_BOUNCE":         PLAYER_ENEMY_BOUNCE,
+	"PLAYER_FRAME_WIDTH":          PLAYER_FRAME_WIDTH,
+	"PLAYER_FRAME_HEIGHT":         PLAYER_FRAME_HEIGHT,
+	"PLAYER_FRAME_TIME":           PLAYER_FRAME_TIME,
+	"PLAYER_STAND_THRESHOLD":      PLAYER_STAND_THRESHOLD,
+	"PLAYER_PREVX_THRESHOLD":      PLAYER_PREVX_THRESHOLD,
+	"PLAYER_JUMP_THRESHOLD":       PLAYER_JUMP_THRESHOLD,
+	"PLAYER_DROP_TIME":            PLAYER_DROP_TIME,
+	"PLAYER_LAND_DUST_VELOCITY":   PLAYER_LAND_DUST_VELOCITY,
+	"PLAYER_MIN_DISTANCE":         PLAYER_MIN_DISTANCE,
+	"PLAYER_MAX_DISTANCE":         PLAYER_MAX_DISTANCE,
+	"PLAYER_NOCLIP_SPEED":         PLAYER_NOCLIP_SPEED,
+	"PLAYER_JUMP_CUT_FACTOR":      PLAYER_JUMP_CUT_FACTOR,
+	"PLAYER_COYOTE_TIME":          PLAYER_COYOTE_TIME,
+	"PLAYER_JUMP_BUFFER":          PLAYER_JUMP_BUFFER,
+}
+
+func init() {
+	consoleCommands = map[string]consoleCommand{
+		"help":      {"help", cmdHelp},
+		"level":     {"level <n>", cmdLevel},
+		"restart":   {"restart", cmdRestart},
+		"give":      {"give <weapon> <ammo>", cmdGive},
+		"god":       {"god", cmdGod},
+		"noclip":    {"noclip", cmdNoclip},
+		"kill":      {"kill", cmdKill},
+		"spawn":     {"spawn enemy <x> <y>", cmdSpawn},
+		"timescale": {"timescale <factor>", cmdTimeScale},
+		"get":       {"get <PLAYER_*>", cmdGet},
+		"vars":      {"vars [prefix]", cmdVars},
+		"movement":  {"movement", cmdMovement},
+		"debugdraw": {"debugdraw", cmdDebugDraw},
+		"win":       {"win", cmdWin},
+		"menu":      {"menu", cmdMenu},
+		"cursor":    {"cursor", cmdCursor},
+		"nilscene":  {"nilscene", cmdNilScene},
+		"dump":      {"dump [file]", cmdDump},
+	}
+}
+
+type DebugConsole struct {
+	gohome.Text2D
+
+	scene      *LevelScene
+	open       bool
+	input      string
+	lines      []string
+	history    []string
+	wasPaused  bool
+	terminated bool
+}
+
+func (this *DebugConsole) Init(scene *LevelScene) {
+	this.scene = scene
+	this.open = false
+	this.input = ""
+	this.terminated = false
+
+	this.Text2D.Init(gohome.ButtonFont, CONSOLE_FONT_SIZE, CONSOLE_PROMPT)
+	this.Text2D.NotRelativeToCamera = 0
+	this.Transform.Position = [2]float32{0.0, float32(GAME_HEIGHT) / 2.0}
+	this.Depth = 255
+	this.Visible = false
+
+	gohome.UpdateMgr.AddObject(this)
+	gohome.RenderMgr.AddObject(this)
+}
+
+func (this *DebugConsole) IsOpen() bool {
+	return this.open
+}
+
+func (this *DebugConsole) setOpen(open bool) {
+	if open == this.open {
+		return
+	}
+	this.open = open
+	this.Visible = open
+	if open {
+		this.wasPaused = LevelUpdates.Paused
+		LevelUpdates.SetPaused(true)
+	} else {
+		LevelUpdates.SetPaused(this.wasPaused)
+	}
+}
+
+func (this *DebugConsole) Print(line string) {
+	this.lines = append(this.lines, strings.Split(line, "\n")...)
+	if len(this.lines) > CONSOLE_LINES {
+		this.lines = this.lines[len(this.lines)-CONSOLE_LINES:]
+	}
+}
+
+func (this *DebugConsole) Exec(line string) {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return
+	}
+	this.Print(CONSOLE_PROMPT + line)
+	this.history = append(this.history, line)
+
+	cmd, ok := consoleCommands[strings.ToLower(args[0])]
+	if !ok {
+		this.Print("Unknown command " + args[0] + ", try help")
+		return
+	}
+	if out := cmd.Run(this.scene, args[1:]); out != "" {
+		this.Print(out)
+	}
+}
+
+func consoleChar(shift bool) (c byte, ok bool) {
+	for i := 0; i < 26; i++ {
+		if gohome.InputMgr.JustPressed(gohome.KeyA + gohome.Key(i)) {
+			if shift {
+				return byte('A' + i), true
+			}
+			return byte('a' + i), true
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if gohome.InputMgr.JustPressed(gohome.Key0 + gohome.Key(i)) {
+			return byte('0' + i), true
+		}
+	}
+	switch {
+	case gohome.InputMgr.JustPressed(gohome.KeySpace):
+		return ' ', true
+	case gohome.InputMgr.JustPressed(gohome.KeyPeriod):
+		return '.', true
+	case gohome.InputMgr.JustPressed(gohome.KeyComma):
+		return ',', true
+	case gohome.InputMgr.JustPressed(gohome.KeyMinus):
+		if shift {
+			return '_', true
+		}
+		return '-', true
+	}
+	return 0, false
+}
+
+func (this *DebugConsole) Update(delta_time float32) {
+	if gohome.InputMgr.JustPressed(CONSOLE_KEY) {
+		this.setOpen(!this.open)
+		return
+	}
+	if !this.open {
+		return
+	}
+
+	switch {
+	case gohome.InputMgr.JustPressed(gohome.KeyEscape):
+		this.setOpen(false)
+		return
+	case gohome.InputMgr.JustPressed(gohome.KeyEnter):
+		line := this.input
+		this.input = ""
+		this.Exec(line)
+		if this.terminated {
+			return
+		}
+	case gohome.InputMgr.JustPressed(gohome.KeyBackspace):
+		if len(this.input) > 0 {
+			this.input = this.input[:len(this.input)-1]
+		}
+	case gohome.InputMgr.JustPressed(gohome.KeyUp):
+		if len(this.history) > 0 {
+			this.input = this.history[len(this.history)-1]
+		}
+	default:
+		if c, ok := consoleChar(gohome.InputMgr.IsPressed(gohome.KeyLeftShift)); ok {
+			this.input += string(c)
+		}
+	}
+
+	this.Text = strings.Join(append(append([]string(nil), this.lines...), CONSOLE_PROMPT+this.input), "\n")
+}
+
+func (this *DebugConsole) Terminate() {
+	if this.terminated {
+		return
+	}
+	this.terminated = true
+	this.open = false
+	this.Text2D.Terminate()
+	gohome.UpdateMgr.RemoveObject(this)
+	gohome.RenderMgr.RemoveObject(this)
+}
+
+func usage(name string) string {
+	return "Usage: " + consoleCommands[name].Usage
+}
+
+func cmdHelp(scn *LevelScene, args []string) string {
+	var names []string
+	for name := range consoleCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+func cmdLevel(scn *LevelScene, args []string) string {
+	if len(args) != 1 {
+		return usage("level")
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > NUM_LEVELS {
+		return "Level has to be between 1 and " + strconv.Itoa(NUM_LEVELS)
+	}
+	gohome.SceneMgr.SwitchScene(&LevelScene{LevelID: uint32(n - 1)})
+	return ""
+}
+
+func cmdRestart(scn *LevelScene, args []string) string {
+	scn.Restart()
+	return ""
+}
+
+func cmdGive(scn *LevelScene, args []string) string {
+	if len(args) != 2 {
+		return usage("give")
+	}
+	ammo, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		return usage("give")
+	}
+	name := strings.ToLower(args[0])
+	if !strings.HasSuffix(name, "weapon") {
+		name += "weapon"
+	}
+	for _, w := range scn.Player.weapons {
+		if weaponName(w) == name {
+			w.SetAmmo(uint32(ammo))
+			return ""
+		}
+	}
+	w := newWeapon(name)
+	if w == nil {
+		return "Unknown weapon " + args[0]
+	}
+	scn.Player.addWeapon(w)
+	w.SetAmmo(uint32(ammo))
+	return ""
+}
+
+func cmdGod(scn *LevelScene, args []string) string {
+	scn.Player.God = !scn.Player.God
+	return "god " + strconv.FormatBool(scn.Player.God)
+}
+
+func cmdNoclip(scn *LevelScene, args []string) string {
+	if scn.Player.Died() {
+		return "Player is dead"
+	}
+	scn.Player.SetNoclip(!scn.Player.Noclip())
+	return "noclip " + strconv.FormatBool(scn.Player.Noclip())
+}
+
+func cmdKill(scn *LevelScene, args []string) string {
+	scn.Player.God = false
+	scn.Player.Die()
+	return ""
+}
+
+func cmdSpawn(scn *LevelScene, args []string) string {
+	if len(args) != 3 || strings.ToLower(args[0]) != "enemy" {
+		return usage("spawn")
+	}
+	x, errx := strconv.ParseFloat(args[1], 32)
+	y, erry := strconv.ParseFloat(args[2], 32)
+	if errx != nil || erry != nil {
+		return usage("spawn")
+	}
+	enemy := &Enemy{}
+	enemy.Init([2]float32{float32(x), float32(y)}, &scn.Player)
+	scn.Enemies = append(scn.Enemies, enemy)
+	return ""
+}
+
+func cmdTimeScale(scn *LevelScene, args []string) string {
+	if len(args) != 1 {
+		return "timescale " + strconv.FormatFloat(float64(CameraFX.BaseTimeScale), 'f', 2, 32)
+	}
+	scale, err := strconv.ParseFloat(args[0], 32)
+	if err != nil || scale <= 0.0 {
+		return usage("timescale")
+	}
+	CameraFX.SetBaseTimeScale(float32(scale))
+	return ""
+}
+
+func cmdGet(scn *LevelScene, args []string) string {
+	if len(args) != 1 {
+		return usage("get")
+	}
+	name := strings.ToUpper(args[0])
+	value, ok := playerTunables[name]
+	if !ok {
+		return "Unknown variable " + args[0]
+	}
+	return fmt.Sprintf("%s = %v", name, value)
+}
+
+func cmdVars(scn *LevelScene, args []string) string {
+	prefix := "PLAYER_"
+	if len(args) == 1 {
+		prefix = strings.ToUpper(args[0])
+	}
+	var names []string
+	for name := range playerTunables {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+func cmdMovement(scn *LevelScene, args []string) string {
+	return fmt.Sprintf("%+v", scn.Player.Movement)
+}
+
+func cmdDebugDraw(scn *LevelScene, args []string) string {
+	scn.debugDraw.Visible = !scn.debugDraw.Visible
+	return ""
+}
+
+func cmdWin(scn *LevelScene, args []string) string {
+	if scn.winMenu.direction == UP {
+		scn.ShowWinMenu()
+	} else {
+		scn.HideWinMenu()
+	}
+	return ""
+}
+
+func cmdMenu(scn *LevelScene, args []string) string {
+	scn.menuDirection = !scn.menuDirection
+	return ""
+}
+
+func cmdCursor(scn *LevelScene, args []string) string {
+	if gohome.Framew.CursorShown() {
+		gohome.Framew.CursorDisable()
+	} else {
+		gohome.Framew.CurserShow()
+	}
+	return ""
+}
+
+func cmdDump(scn *LevelScene, args []string) string {
+	fileName := DEBUG_CSV_FILE
+	if len(args) == 1 {
+		fileName = args[0]
+	}
+	return scn.toggleDump(fileName)
+}
+
+func cmdNilScene(scn *LevelScene, args []string) string {
+	gohome.SceneMgr.SwitchScene(&gohome.NilScene{})
+	return ""
+}
@@ -0,0 +1,17 @@
+//go:build !debug
+// +build !debug
+
+package main
+
+type DebugConsole struct {
+}
+
+func (this *DebugConsole) Init(scene *LevelScene) {
+}
+
+func (this *DebugConsole) IsOpen() bool {
+	return false
+}
+
+func (this *DebugConsole) Terminate() {
+}
@@ -1,17 +1,98 @@
 package main
 
 import (
+	"encoding/csv"
 	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
+	"golang.org/x/image/colornames"
+	"os"
 	"strconv"
 )
 
+const (
+	DEBUG_INFO_FONT_SIZE = 16
+	DEBUG_CSV_FILE       = "profile.csv"
+	FRAME_GRAPH_FRAMES   = 120
+
+	FRAME_GRAPH_BAR_WIDTH       float32 = 2.0
+	FRAME_GRAPH_MS_HEIGHT       float32 = 3.0
+	FRAME_GRAPH_MAX_HEIGHT      float32 = 150.0
+	FRAME_GRAPH_TARGET_MS       float32 = 1000.0 / 60.0
+	FRAME_GRAPH_OFFSET_RIGHT_X  float32 = 10.0
+	FRAME_GRAPH_OFFSET_BOTTOM_Y float32 = 10.0
+)
+
+const (
+	PROFILE_PHYSICS ProfileSection = iota
+	PROFILE_PLAYER
+	PROFILE_ENEMIES
+	PROFILE_INVENTORY
+	NUM_PROFILE_SECTIONS
+)
+
+var PROFILE_SECTION_NAMES = [NUM_PROFILE_SECTIONS]string{
+	"Physics",
+	"Player",
+	"Enemies",
+	"Inventory",
+}
+
+type ProfileSection uint8
+
+type DebugFrame struct {
+	FrameTime float32
+	Sections  [NUM_PROFILE_SECTIONS]float32
+	Bodies    int
+	Contacts  int
+	Grounded  bool
+	Velocity  [2]float32
+	Weapon    string
+	Ammo      uint32
+}
+
+func (this *DebugFrame) record() []string {
+	f := func(v float32) string {
+		return strconv.FormatFloat(float64(v), 'f', 3, 32)
+	}
+	rec := []string{f(this.FrameTime)}
+	for _, ms := range this.Sections {
+		rec = append(rec, f(ms))
+	}
+	return append(rec,
+		strconv.Itoa(this.Bodies),
+		strconv.Itoa(this.Contacts),
+		strconv.FormatBool(this.Grounded),
+		f(this.Velocity[0]),
+		f(this.Velocity[1]),
+		this.Weapon,
+		strconv.FormatUint(uint64(this.Ammo), 10),
+	)
+}
+
+func debugFrameHeader() []string {
+	header := []string{"frame_ms"}
+	for _, name := range PROFILE_SECTION_NAMES {
+		header = append(header, name+"_ms")
+	}
+	return append(header, "bodies", "contacts", "grounded", "vel_x", "vel_y", "weapon", "ammo")
+}
+
 type DebugInfo struct {
 	gohome.Text2D
+
+	Player *Player
+	Graph  FrameGraph
+
+	dumpFile   *os.File
+	dumpWriter *csv.Writer
 }
 
-func (this *DebugInfo) Init() {
-	this.Text2D.Init(gohome.ButtonFont, 24, "FPS: 60\nUOBJs: 0\nROBJs: 0")
+func (this *DebugInfo) Init(player *Player) {
+	this.Player = player
+	this.Text2D.Init(gohome.ButtonFont, DEBUG_INFO_FONT_SIZE, "FPS: 60")
 	this.Text2D.NotRelativeToCamera = 0
+	this.Graph.Init()
+	Profile.Init()
 	gohome.UpdateMgr.AddObject(this)
 	gohome.RenderMgr.AddObject(this)
 
@@ -19,16 +100,167 @@ func (this *DebugInfo) Init() {
 	this.Visible = false
 }
 
+func (this *DebugInfo) collect(delta_time float32) (frame DebugFrame) {
+	frame.FrameTime = delta_time * 1000.0
+	frame.Sections = Profile.Flush()
+	frame.Bodies = PhysicsMgr.World.GetBodyCount()
+	frame.Contacts = PhysicsMgr.World.GetContactCount()
+
+	p := this.Player
+	if p.Died() {
+		return
+	}
+	frame.Grounded = p.IsGrounded()
+	frame.Velocity = physics2d.ToPixelDirection(p.body.GetLinearVelocity())
+	if len(p.weapons) != 0 {
+		w := p.weapons[p.currentWeapon]
+		frame.Weapon = weaponName(w)
+		frame.Ammo = w.GetAmmo()
+	}
+	return
+}
+
 func (this *DebugInfo) Update(delta_time float32) {
-	if this.Visible {
-		this.Text = "FPS: " + strconv.FormatFloat(float64(1.0/delta_time), 'f', 1, 32) + "\n" +
-			"UOBJs: " + strconv.FormatUint(uint64(gohome.UpdateMgr.NumUpdateObjects()), 10) + "\n" +
-			"ROBJs: " + strconv.FormatUint(uint64(gohome.RenderMgr.NumRenderObjects()), 10)
+	this.Graph.Visible = this.Visible
+	if !this.Visible && !this.Dumping() {
+		Profile.Flush()
+		return
+	}
+	frame := this.collect(delta_time)
+	if this.dumpWriter != nil {
+		this.dumpWriter.Write(frame.record())
+	}
+	if !this.Visible {
+		return
+	}
+	this.Graph.Add(frame.FrameTime)
+
+	ms := func(v float32) string {
+		return strconv.FormatFloat(float64(v), 'f', 2, 32) + " ms"
+	}
+	this.Text = "FPS: " + strconv.FormatFloat(float64(1.0/delta_time), 'f', 1, 32) + "\n" +
+		"UOBJs: " + strconv.FormatUint(uint64(gohome.UpdateMgr.NumUpdateObjects()+LevelUpdates.NumObjects()), 10) + "\n" +
+		"ROBJs: " + strconv.FormatUint(uint64(gohome.RenderMgr.NumRenderObjects()), 10) + "\n" +
+		"ENTs: " + strconv.FormatUint(uint64(Entities.NumEntities()), 10) + "\n" +
+		"Bodies: " + strconv.Itoa(frame.Bodies) + "\n" +
+		"Contacts: " + strconv.Itoa(frame.Contacts) + "\n"
+	for i, name := range PROFILE_SECTION_NAMES {
+		this.Text += name + ": " + ms(frame.Sections[i]) + "\n"
+	}
+	this.Text += "Grounded: " + strconv.FormatBool(frame.Grounded) + "\n" +
+		"Velocity: " + strconv.FormatFloat(float64(frame.Velocity[0]), 'f', 1, 32) + ", " + strconv.FormatFloat(float64(frame.Velocity[1]), 'f', 1, 32) + "\n" +
+		"Weapon: " + frame.Weapon + " (" + strconv.FormatUint(uint64(frame.Ammo), 10) + ")"
+	if this.dumpWriter != nil {
+		this.Text += "\nDumping to " + this.dumpFile.Name()
+	}
+}
+
+func (this *DebugInfo) Dumping() bool {
+	return this.dumpWriter != nil
+}
+
+func (this *DebugInfo) StartDump(fileName string) {
+	this.StopDump()
+	file, err := os.Create(fileName)
+	if err != nil {
+		gohome.ErrorMgr.Error("DebugInfo", fileName, err.Error())
+		return
+	}
+	this.dumpFile = file
+	this.dumpWriter = csv.NewWriter(file)
+	this.dumpWriter.Write(debugFrameHeader())
+}
+
+func (this *DebugInfo) StopDump() {
+	if this.dumpWriter == nil {
+		return
+	}
+	this.dumpWriter.Flush()
+	if err := this.dumpWriter.Error(); err != nil {
+		gohome.ErrorMgr.Error("DebugInfo", this.dumpFile.Name(), err.Error())
 	}
+	this.dumpFile.Close()
+	this.dumpWriter = nil
+	this.dumpFile = nil
 }
 
 func (this *DebugInfo) Terminate() {
+	this.StopDump()
+	this.Graph.Terminate()
 	this.Text2D.Terminate()
 	gohome.UpdateMgr.RemoveObject(this)
 	gohome.RenderMgr.RemoveObject(this)
 }
+
+type FrameGraph struct {
+	gohome.Shape2D
+
+	times  []float32
+	loaded bool
+}
+
+func (this *FrameGraph) Init() {
+	this.times = nil
+	this.loaded = false
+	this.rebuild()
+	gohome.RenderMgr.AddObject(this)
+}
+
+func (this *FrameGraph) Add(ms float32) {
+	this.times = append(this.times, ms)
+	if len(this.times) > FRAME_GRAPH_FRAMES {
+		this.times = this.times[len(this.times)-FRAME_GRAPH_FRAMES:]
+	}
+	this.rebuild()
+}
+
+func (this *FrameGraph) height(ms float32) float32 {
+	if h := ms * FRAME_GRAPH_MS_HEIGHT; h < FRAME_GRAPH_MAX_HEIGHT {
+		return h
+	}
+	return FRAME_GRAPH_MAX_HEIGHT
+}
+
+func (this *FrameGraph) rebuild() {
+	visible := this.Visible
+	if this.loaded {
+		this.Shape2D.Terminate()
+	}
+	this.Shape2D.Init()
+	this.loaded = true
+	this.NotRelativeToCamera = 0
+	this.Depth = 255
+	this.Visible = visible
+
+	bottom := float32(GAME_HEIGHT) - FRAME_GRAPH_OFFSET_BOTTOM_Y
+	right := float32(GAME_WIDTH) - FRAME_GRAPH_OFFSET_RIGHT_X
+	left := right - FRAME_GRAPH_FRAMES*FRAME_GRAPH_BAR_WIDTH
+	target := bottom - this.height(FRAME_GRAPH_TARGET_MS)
+
+	lines := make([]gohome.Line2D, 0, len(this.times)+1)
+	var line gohome.Line2D
+	line[0].Make([2]float32{left, target}, colornames.Yellow)
+	line[1].Make([2]float32{right, target}, colornames.Yellow)
+	lines = append(lines, line)
+	for i, ms := range this.times {
+		col := colornames.Lime
+		if ms > FRAME_GRAPH_TARGET_MS {
+			col = colornames.Red
+		}
+		x := left + float32(i)*FRAME_GRAPH_BAR_WIDTH
+		line[0].Make([2]float32{x, bottom}, col)
+		line[1].Make([2]float32{x, bottom - this.height(ms)}, col)
+		lines = append(lines, line)
+	}
+	this.AddLines(lines)
+	this.Load()
+	this.SetDrawMode(gohome.DRAW_MODE_LINES)
+}
+
+func (this *FrameGraph) Terminate() {
+	gohome.RenderMgr.RemoveObject(this)
+	if this.loaded {
+		this.Shape2D.Terminate()
+		this.loaded = false
+	}
+}
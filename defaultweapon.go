@@ -31,7 +31,7 @@ func (this *DefaultWeapon) OnAdd(p *Player) {
 	this.NilWeapon.OnAdd(p)
 	this.Ammo = DEFAULT_WEAPON_AMMO
 
-	gohome.UpdateMgr.AddObject(this)
+	LevelUpdates.AddObject(this)
 }
 
 func (this *DefaultWeapon) GetInventoryTexture() gohome.Texture {
@@ -61,7 +61,7 @@ func (this *DefaultWeapon) createBox(dir mgl32.Vec2, energy float32) {
 	fdef.Friction = DEFAULT_WEAPON_FRICTION
 	fdef.Density = 1.0 / (physics2d.ScalarToBox2D(DEFAULT_WEAPON_WIDTH) * physics2d.ScalarToBox2D(DEFAULT_WEAPON_HEIGHT)) * DEFAULT_WEAPON_WEIGHT
 	fdef.Restitution = DEFAULT_WEAPON_RESTITUTION
-	fdef.Filter.CategoryBits = WEAPON_CATEGORY
+	fdef.Filter = CollisionFilter(WEAPON_CATEGORY)
 	shape := box2d.MakeB2PolygonShape()
 	shape.SetAsBox(physics2d.ScalarToBox2D(size[0])/2.0, physics2d.ScalarToBox2D(size[1])/2.0)
 	fdef.Shape = &shape
@@ -71,30 +71,17 @@ func (this *DefaultWeapon) createBox(dir mgl32.Vec2, energy float32) {
 	body.SetLinearVelocity(physics2d.ToBox2DDirection(dir.Mul(DEFAULT_WEAPON_VELOCITY * energy)))
 	body.SetLinearVelocity(box2d.B2Vec2Add(this.Player.body.GetLinearVelocity(), body.GetLinearVelocity()))
 
-	var spr gohome.Sprite2D
-	var con physics2d.PhysicsConnector2D
-
-	spr.Init("DefaultWeaponBlock")
-	con.Init(spr.Transform, body, &PhysicsMgr)
-
-	gohome.RenderMgr.AddObject(&spr)
-
-	var block WeaponBlock
-	block.Sprite = &spr
-	block.Connector = &con
-	this.blocks = append(this.blocks, block)
-
-	body.SetUserData(&this.blocks[len(this.blocks)-1])
-
-	con.Update()
+	block := &WeaponBlock{}
+	block.Init(BLOCK_DEFAULT, this, "DefaultWeaponBlock", body)
+	this.addBlock(block)
 }
 
 func (this *DefaultWeapon) OnDie() {
-	gohome.UpdateMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(this)
 	gohome.RenderMgr.RemoveObject(&this.NilWeapon)
 }
 
 func (this *DefaultWeapon) Terminate() {
 	this.NilWeapon.Terminate()
-	gohome.UpdateMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(this)
 }
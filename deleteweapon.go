@@ -19,30 +19,12 @@ const (
 	DELETE_WEAPON_OFFSET_Y float32 = -2.0
 )
 
-type TerminateObject interface {
-	Terminate()
-}
-
 type DeleteWeapon struct {
 	NilWeapon
 
 	sparcles []*Sparcles
 }
 
-func (this *DeleteWeapon) Pause() {
-	this.NilWeapon.Pause()
-	for i := 0; i < len(this.sparcles); i++ {
-		this.sparcles[i].paused = true
-	}
-}
-
-func (this *DeleteWeapon) Resume() {
-	this.NilWeapon.Resume()
-	for i := 0; i < len(this.sparcles); i++ {
-		this.sparcles[i].paused = false
-	}
-}
-
 func (this *DeleteWeapon) OnAdd(p *Player) {
 	this.Sprite2D.Init("DeleteWeapon")
 	this.Transform.Origin = [2]float32{0.5, 0.5}
@@ -50,7 +32,7 @@ func (this *DeleteWeapon) OnAdd(p *Player) {
 	this.NilWeapon.OnAdd(p)
 	this.Ammo = DELETE_WEAPON_AMMO
 
-	gohome.UpdateMgr.AddObject(this)
+	LevelUpdates.AddObject(this)
 }
 
 func (this *DeleteWeapon) GetInventoryTexture() gohome.Texture {
@@ -68,7 +50,7 @@ func (this *DeleteWeapon) castRay(dir mgl32.Vec2) {
 	var bodies []*box2d.B2Body
 	for b := w.GetBodyList(); b != nil; b = b.GetNext() {
 		for f := b.GetFixtureList(); f != nil; f = f.GetNext() {
-			if f.GetFilterData().CategoryBits&WEAPON_CATEGORY == WEAPON_CATEGORY {
+			if f.GetFilterData().CategoryBits&WEAPON_CATEGORIES != 0 {
 				hits := f.RayCast(&output, input, 0)
 				if hits {
 					bodies = append(bodies, b)
@@ -78,14 +60,7 @@ func (this *DeleteWeapon) castRay(dir mgl32.Vec2) {
 	}
 
 	for i := 0; i < len(bodies); i++ {
-		var disappeared bool = true
-		for f := bodies[i].GetFixtureList(); f != nil; f = f.GetNext() {
-			if i, ok := f.GetUserData().(*int); !(ok && *i == 1) {
-				disappeared = false
-				break
-			}
-		}
-		if !disappeared {
+		if block := GetBlock(bodies[i]); block != nil && !block.base().deleting {
 			this.sparcles = append(this.sparcles, disappear(bodies[i], w, this))
 		}
 	}
@@ -108,12 +83,14 @@ func (this *DeleteWeapon) Use(target mgl32.Vec2, energy float32) {
 	ray.Transform.Position = this.Player.Transform.Position.Add(dir.Mul(DELETE_WEAPON_DISTANCE / 2.0)).Add(this.Player.GetWeaponOffset()).Sub([2]float32{0.0, DELETE_RAYS_WIDTH / 2.0})
 
 	ray.Transform.Rotation = mgl32.RadToDeg(-dir.Angle())
+	start := this.Player.Transform.Position.Add(this.Player.GetWeaponOffset())
+	Particles.SpawnLine("sparks", start, start.Add(dir.Mul(DELETE_WEAPON_DISTANCE)))
 	this.castRay(dir)
 }
 
 func (this *DeleteWeapon) Terminate() {
 	this.NilWeapon.Terminate()
-	gohome.UpdateMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(this)
 
 	for len(this.sparcles) > 0 {
 		this.sparcles[0].Terminate()
@@ -138,7 +115,8 @@ func (this *DeleteRay) Init() {
 	this.SetDrawMode(gohome.DRAW_MODE_TRIANGLES)
 
 	gohome.RenderMgr.AddObject(this)
-	gohome.UpdateMgr.AddObject(this)
+	LevelUpdates.AddObject(this)
+	Entities.Add(this)
 
 	this.Transform.Size = [2]float32{DELETE_WEAPON_DISTANCE, DELETE_RAYS_WIDTH}
 	this.Depth = DELETE_RAY_DEPTH
@@ -147,9 +125,15 @@ func (this *DeleteRay) Init() {
 func (this *DeleteRay) Update(delta_time float32) {
 	this.time += delta_time
 	if this.time >= DELETE_RAYS_SPEED {
-		gohome.RenderMgr.RemoveObject(this)
-		gohome.UpdateMgr.RemoveObject(this)
+		Entities.Destroy(this)
+		return
 	}
 	width := DELETE_RAYS_WIDTH * (1.0 - this.time/DELETE_RAYS_SPEED)
 	this.Transform.Size[1] = width
 }
+
+func (this *DeleteRay) Terminate() {
+	gohome.RenderMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(this)
+	this.Shape2D.Terminate()
+}
@@ -38,19 +38,20 @@ const (
 type Enemy struct {
 	gohome.Sprite2D
 	Body            *box2d.B2Body
-	connector       physics2d.PhysicsConnector2D
+	connector       Connector
 	Player          *Player
 	direction       bool
 	terminated      bool
 	destructionTime float32
 	destructed      bool
-	paused          bool
+	sensors         [4]ContactCounter
 
 	anim gohome.Tweenset
 }
 
 func (this *Enemy) Init(pos mgl32.Vec2, player *Player) {
 	this.Sprite2D.Init("Enemy")
+	this.Depth = MAP_DEPTH
 	this.Transform.Position = pos
 	this.Transform.Origin = [2]float32{0.5, 0.5}
 	this.Player = player
@@ -59,16 +60,18 @@ func (this *Enemy) Init(pos mgl32.Vec2, player *Player) {
 
 	this.createBody()
 
-	gohome.UpdateMgr.AddObject(this)
+	LevelUpdates.AddObject(this)
 	gohome.RenderMgr.AddObject(this)
-	this.connector.Init(this.Transform, this.Body, &PhysicsMgr)
+	Stepper.AddController(this)
+	Entities.Add(this)
+	this.connector.Init(this.Transform, this.Body)
 	this.connector.Offset = [2]float32{ENEMY_OFFSET_X, ENEMY_OFFSET_Y}
 
 	this.anim = gohome.SpriteAnimation2D(this.Texture, 3, 4, ENEMY_FRAME_TIME)
 	this.anim.Loop = true
 	this.anim.SetParent(&this.Sprite2D)
 	this.anim.Start()
-	gohome.UpdateMgr.AddObject(&this.anim)
+	LevelUpdates.AddObject(&this.anim)
 
 	this.TextureRegion.Max = [2]float32{
 		ENEMY_FRAME_WIDTH,
@@ -86,8 +89,7 @@ func (this *Enemy) createBody() {
 	radius := physics2d.ScalarToBox2D(ENEMY_RADIUS)
 
 	fdef := box2d.MakeB2FixtureDef()
-	fdef.Filter.CategoryBits = ENEMY_CATEGORY
-	fdef.Filter.MaskBits = 0xffff
+	fdef.Filter = CollisionFilter(ENEMY_CATEGORY)
 	fdef.Friction = ENEMY_FRICTION
 	fdef.Density = 1.0 / (2.0 * math.Pi * radius * radius) * ENEMY_WEIGHT
 	fdef.Restitution = ENEMY_RESTITUTION
@@ -102,7 +104,7 @@ func (this *Enemy) createBody() {
 	this.Body.CreateFixtureFromDef(&fdef)
 
 	fdef.IsSensor = true
-	fdef.Filter.CategoryBits = ENEMY_SMALL_LEFT_SENSOR_CATEGORY
+	fdef.Filter = CollisionFilter(ENEMY_SMALL_LEFT_SENSOR_CATEGORY)
 	sshape := box2d.MakeB2PolygonShape()
 	offset := physics2d.ToBox2DDirection([2]float32{ENEMY_SMALL_SENSOR_OFFSET_X, ENEMY_SMALL_SENSOR_OFFSET_Y})
 	sshape.SetAsBox(physics2d.ScalarToBox2D(ENEMY_SMALL_SENSOR_WIDTH)/2.0, physics2d.ScalarToBox2D(ENEMY_SMALL_SENSOR_HEIGHT)/2.0)
@@ -118,7 +120,7 @@ func (this *Enemy) createBody() {
 		v := &sshape.M_vertices[i]
 		v.X -= offset.X * 2.0
 	}
-	fdef.Filter.CategoryBits = ENEMY_SMALL_RIGHT_SENSOR_CATEGORY
+	fdef.Filter = CollisionFilter(ENEMY_SMALL_RIGHT_SENSOR_CATEGORY)
 
 	this.Body.CreateFixtureFromDef(&fdef)
 
@@ -128,7 +130,7 @@ func (this *Enemy) createBody() {
 		v := &sshape.M_vertices[i]
 		*v = box2d.B2Vec2Add(*v, offset)
 	}
-	fdef.Filter.CategoryBits = ENEMY_BIG_LEFT_SENSOR_CATEGORY
+	fdef.Filter = CollisionFilter(ENEMY_BIG_LEFT_SENSOR_CATEGORY)
 
 	this.Body.CreateFixtureFromDef(&fdef)
 
@@ -136,16 +138,32 @@ func (this *Enemy) createBody() {
 		v := &sshape.M_vertices[i]
 		v.X -= offset.X * 2.0
 	}
-	fdef.Filter.CategoryBits = ENEMY_BIG_RIGHT_SENSOR_CATEGORY
+	fdef.Filter = CollisionFilter(ENEMY_BIG_RIGHT_SENSOR_CATEGORY)
 
 	this.Body.CreateFixtureFromDef(&fdef)
+
+	this.initSensors()
+}
+
+func (this *Enemy) initSensors() {
+	categories := [4]uint16{
+		ENEMY_SMALL_LEFT_SENSOR_CATEGORY,
+		ENEMY_SMALL_RIGHT_SENSOR_CATEGORY,
+		ENEMY_BIG_LEFT_SENSOR_CATEGORY,
+		ENEMY_BIG_RIGHT_SENSOR_CATEGORY,
+	}
+	for i, category := range categories {
+		this.sensors[i].Init(ContactFilter{Body: this.Body, Category: category})
+	}
 }
 
 func (this *Enemy) Die() {
+	CameraFX.AddTrauma(CAMERA_KILL_TRAUMA)
+	CameraFX.SlowMotion(CAMERA_KILL_SLOW_TIME, CAMERA_KILL_SLOW_FACTOR)
+	Particles.Spawn("debris", this.Transform.Position)
 	var exp Explosion
-	exp.Init("Explosion")
+	exp.Init("Explosion", this.Transform.Position)
 	exp.Transform.Origin = [2]float32{0.5, 0.5}
-	exp.Transform.Position = this.Transform.Position
 	exp.anim = gohome.SpriteAnimation2D(exp.Texture, 5, 1, 1.0/8.0)
 	exp.anim.Tweens = append(exp.anim.Tweens, &gohome.TweenWait{
 		TweenType: gohome.TWEEN_TYPE_AFTER_PREVIOUS,
@@ -154,55 +172,14 @@ func (this *Enemy) Die() {
 	exp.anim.SetParent(&exp.Sprite2D)
 	exp.anim.Start()
 	gohome.RenderMgr.AddObject(&exp)
-	gohome.UpdateMgr.AddObject(&exp.anim)
-	gohome.UpdateMgr.AddObject(&exp)
+	LevelUpdates.AddObject(&exp.anim)
+	LevelUpdates.AddObject(&exp)
+	Entities.Add(&exp)
 }
 
 func (this *Enemy) checkCollisions() {
-	var sl, sr, bl, br bool = false, false, false, false
-	for ce := this.Body.GetContactList(); ce != nil; ce = ce.Next {
-		c := ce.Contact
-		if !c.IsTouching() {
-			continue
-		}
-		fa := c.GetFixtureA()
-		fb := c.GetFixtureB()
-
-		if fb.GetBody() == this.Body {
-			fa, fb = fb, fa
-		}
-
-		if fb.GetBody() == this.Body {
-			continue
-		}
-
-		if fa.GetFilterData().CategoryBits&ENEMY_SENSOR_CATEGORY == 0 {
-			continue
-		}
-
-		if fb.GetFilterData().CategoryBits&GROUND_CATEGORY == 0 &&
-			fb.GetFilterData().CategoryBits&WEAPON_CATEGORY == 0 &&
-			fb.GetFilterData().CategoryBits&ENEMY_CATEGORY == 0 {
-			continue
-		}
-
-		switch fa.GetFilterData().CategoryBits {
-		case ENEMY_SMALL_LEFT_SENSOR_CATEGORY:
-			if fb.GetFilterData().CategoryBits == ENEMY_CATEGORY {
-				continue
-			}
-			sl = true
-		case ENEMY_SMALL_RIGHT_SENSOR_CATEGORY:
-			if fb.GetFilterData().CategoryBits == ENEMY_CATEGORY {
-				continue
-			}
-			sr = true
-		case ENEMY_BIG_LEFT_SENSOR_CATEGORY:
-			bl = true
-		case ENEMY_BIG_RIGHT_SENSOR_CATEGORY:
-			br = true
-		}
-	}
+	sl, sr := this.sensors[0].Touching(), this.sensors[1].Touching()
+	bl, br := this.sensors[2].Touching(), this.sensors[3].Touching()
 	if !sl {
 		this.direction = RIGHT
 	}
@@ -243,10 +220,7 @@ func (this *Enemy) updateAnimation() {
 }
 
 func (this *Enemy) Update(delta_time float32) {
-	if this.paused {
-		return
-	}
-
+	defer Profile.End(PROFILE_ENEMIES, Profile.Start())
 	disttoplayer := this.Transform.Position.Sub(this.Player.Transform.Position).Len2()
 	if disttoplayer > AI_DISTANCE*AI_DISTANCE {
 		if this.Body.IsActive() {
@@ -258,8 +232,6 @@ func (this *Enemy) Update(delta_time float32) {
 		this.Body.SetActive(true)
 	}
 
-	this.checkCollisions()
-	this.updateVelocity()
 	this.updateAnimation()
 
 	if this.destructed {
@@ -272,15 +244,30 @@ func (this *Enemy) Update(delta_time float32) {
 	}
 }
 
+func (this *Enemy) FixedUpdate(delta_time float32) {
+	defer Profile.End(PROFILE_ENEMIES, Profile.Start())
+	if !this.Body.IsActive() {
+		return
+	}
+
+	this.checkCollisions()
+	this.updateVelocity()
+}
+
 func (this *Enemy) Terminate() {
 	if this.terminated {
 		return
 	}
 
+	for i := range this.sensors {
+		this.sensors[i].Terminate()
+	}
 	this.Player.PhysicsMgr.World.DestroyBody(this.Body)
-	gohome.UpdateMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(this)
 	gohome.RenderMgr.RemoveObject(this)
-	gohome.UpdateMgr.RemoveObject(&this.anim)
+	LevelUpdates.RemoveObject(&this.anim)
+	Stepper.RemoveController(this)
+	Entities.Remove(this)
 	this.connector.Terminate()
 
 	this.terminated = true
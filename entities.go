@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"strconv"
+	"strings"
+)
+
+type Entity interface {
+	Terminate()
+}
+
+type EntityRegistry struct {
+	entities  []Entity
+	numRender uint32
+	numUpdate uint32
+}
+
+func (this *EntityRegistry) Init() {
+	this.entities = nil
+	this.numRender = gohome.RenderMgr.NumRenderObjects()
+	this.numUpdate = gohome.UpdateMgr.NumUpdateObjects()
+}
+
+func (this *EntityRegistry) Add(entity Entity) {
+	this.entities = append(this.entities, entity)
+}
+
+func (this *EntityRegistry) Remove(entity Entity) {
+	for i := 0; i < len(this.entities); i++ {
+		if this.entities[i] == entity {
+			this.entities = append(this.entities[:i], this.entities[i+1:]...)
+			return
+		}
+	}
+}
+
+func (this *EntityRegistry) Destroy(entity Entity) {
+	this.Remove(entity)
+	entity.Terminate()
+}
+
+func (this *EntityRegistry) NumEntities() uint32 {
+	return uint32(len(this.entities))
+}
+
+// Destroys every entity, the latest first so nothing outlives what it was
+// created from
+func (this *EntityRegistry) Terminate() {
+	for len(this.entities) != 0 {
+		this.Destroy(this.entities[len(this.entities)-1])
+	}
+}
+
+// Leaked objects are grouped by type and listed by address so they can be
+// told apart in the log
+type leakReport map[string][]string
+
+func (this leakReport) add(obj interface{}) {
+	name := fmt.Sprintf("%T", obj)
+	this[name] = append(this[name], fmt.Sprintf("%p", obj))
+}
+
+func (this leakReport) warn(what string) {
+	for name, objs := range this {
+		gohome.ErrorMgr.Warning("Entities", name, strconv.Itoa(len(objs))+" object(s) "+what+" after the level has been terminated: "+strings.Join(objs, ", "))
+	}
+}
+
+func (this *EntityRegistry) CheckLeaks() {
+	updating := make(leakReport)
+	for _, obj := range LevelUpdates.objects {
+		if obj != nil {
+			updating.add(obj)
+		}
+	}
+	updating.warn("still updating")
+
+	stepping := make(leakReport)
+	for _, c := range Stepper.controllers {
+		if c != nil {
+			stepping.add(c)
+		}
+	}
+	for _, c := range Stepper.connectors {
+		if c == nil {
+			continue
+		}
+		if owner := c.Body.GetUserData(); owner != nil {
+			stepping.add(owner)
+		} else {
+			stepping.add(c)
+		}
+	}
+	stepping.warn("still stepping")
+
+	// The managers of gohome can't be listed, so only the number is known
+	if num := gohome.RenderMgr.NumRenderObjects(); num > this.numRender {
+		gohome.ErrorMgr.Warning("Entities", "RenderMgr", strconv.FormatUint(uint64(num-this.numRender), 10)+" object(s) left behind after the level has been terminated")
+	}
+	if num := gohome.UpdateMgr.NumUpdateObjects(); num > this.numUpdate {
+		gohome.ErrorMgr.Warning("Entities", "UpdateMgr", strconv.FormatUint(uint64(num-this.numUpdate), 10)+" object(s) left behind after the level has been terminated")
+	}
+}
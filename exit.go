@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+)
+
+type Exit struct {
+	Position mgl32.Vec2
+	Size     mgl32.Vec2
+	Map      string
+	Entry    string
+}
+
+func (this *Exit) Finishes() bool {
+	return this.Map == ""
+}
+
+func (this *Exit) Contains(p *Player) bool {
+	ppos := p.Transform.Position
+	psize := p.Transform.Size.MulVec(p.Transform.Scale)
+	ppos = ppos.Sub(psize.Mul(0.5))
+
+	return ppos[0] < this.Position[0]+this.Size[0] &&
+		ppos[0]+psize[0] > this.Position[0] &&
+		ppos[1] < this.Position[1]+this.Size[1] &&
+		ppos[1]+psize[1] > this.Position[1]
+}
+
+type WeaponState struct {
+	Name string
+	Ammo uint32
+}
+
+func newWeapon(name string) Weapon {
+	switch name {
+	case "defaultweapon":
+		return &DefaultWeapon{}
+	case "freezeweapon":
+		return &FreezeWeapon{}
+	case "ballweapon":
+		return &BallWeapon{}
+	case "moveweapon":
+		return &MoveWeapon{}
+	case "deleteweapon":
+		return &DeleteWeapon{}
+	}
+	return nil
+}
+
+func weaponName(w Weapon) string {
+	switch w.(type) {
+	case *DefaultWeapon:
+		return "defaultweapon"
+	case *FreezeWeapon:
+		return "freezeweapon"
+	case *BallWeapon:
+		return "ballweapon"
+	case *MoveWeapon:
+		return "moveweapon"
+	case *DeleteWeapon:
+		return "deleteweapon"
+	}
+	return ""
+}
@@ -24,10 +24,13 @@ const (
 	FREEZE_FRAME_HEIGHT float32 = 16.0
 )
 
+type FreezeBlock struct {
+	WeaponBlock
+	time float32
+}
+
 type FreezeWeapon struct {
 	NilWeapon
-	bodies []*box2d.B2Body
-	times  []float32
 }
 
 func (this *FreezeWeapon) OnAdd(p *Player) {
@@ -35,7 +38,7 @@ func (this *FreezeWeapon) OnAdd(p *Player) {
 	this.Transform.Origin = [2]float32{0.5, 0.5}
 
 	this.NilWeapon.OnAdd(p)
-	gohome.UpdateMgr.AddObject(this)
+	LevelUpdates.AddObject(this)
 	this.Ammo = FREEZE_AMMO
 }
 
@@ -51,18 +54,19 @@ func (this *FreezeWeapon) Update(delta_time float32) {
 	}
 	this.Transform.Position = this.Player.Transform.Position.Add(this.Player.GetWeaponOffset()).Add(off)
 
-	if this.paused {
-		return
-	}
-
-	for i := 0; i < len(this.times); i++ {
-		if this.times[i] > 0.0 {
-			this.times[i] -= delta_time
+	for _, b := range this.blocks {
+		block := b.(*FreezeBlock)
+		if block.time > 0.0 {
+			block.time -= delta_time
 		}
-		if this.times[i] <= 0.0 {
-			this.bodies[i].SetType(box2d.B2BodyType.B2_staticBody)
-			block := this.bodies[i].GetUserData().(*WeaponBlock)
+		if block.time <= 0.0 && block.Body.GetType() != box2d.B2BodyType.B2_staticBody {
+			block.Body.SetType(box2d.B2BodyType.B2_staticBody)
+			if ONE_WAY_FREEZE_BLOCKS {
+				block.Body.GetFixtureList().SetUserData(&OneWay{})
+			}
 			block.Sprite.TextureRegion.Min[0], block.Sprite.TextureRegion.Max[0] = FREEZE_FRAME_WIDTH, FREEZE_FRAME_WIDTH*2
+			Mixer.PlayLimitedAt("FreezeLock", CHANNEL_SFX, block.Sprite.Transform.Position, 1.0, FREEZE_SOUND_INTERVAL)
+			Particles.Spawn("freeze", block.Sprite.Transform.Position)
 		}
 	}
 
@@ -70,13 +74,11 @@ func (this *FreezeWeapon) Update(delta_time float32) {
 
 func (this *FreezeWeapon) Use(target mgl32.Vec2, energy float32) {
 	dir := target.Sub(this.Player.Transform.Position).Normalize()
-	body := this.createBox(dir, energy)
-	this.bodies = append(this.bodies, body)
-	this.times = append(this.times, FREEZE_TIME)
+	this.createBox(dir, energy)
 	this.Ammo--
 }
 
-func (this *FreezeWeapon) createBox(dir mgl32.Vec2, energy float32) *box2d.B2Body {
+func (this *FreezeWeapon) createBox(dir mgl32.Vec2, energy float32) {
 	pos := this.Player.Transform.Position.Add(dir.Mul(PLAYER_WIDTH * 2.0))
 	size := [2]float32{FREEZE_WIDTH, FREEZE_HEIGHT}
 
@@ -88,7 +90,7 @@ func (this *FreezeWeapon) createBox(dir mgl32.Vec2, energy float32) *box2d.B2Bod
 	fdef.Friction = FREEZE_FRICTION
 	fdef.Density = 1.0 / (physics2d.ScalarToBox2D(FREEZE_WIDTH) * physics2d.ScalarToBox2D(FREEZE_HEIGHT)) * DEFAULT_WEAPON_WEIGHT
 	fdef.Restitution = DEFAULT_WEAPON_RESTITUTION
-	fdef.Filter.CategoryBits = WEAPON_CATEGORY
+	fdef.Filter = CollisionFilter(WEAPON_CATEGORY)
 	shape := box2d.MakeB2PolygonShape()
 	shape.SetAsBox(physics2d.ScalarToBox2D(size[0])/2.0, physics2d.ScalarToBox2D(size[1])/2.0)
 	fdef.Shape = &shape
@@ -98,35 +100,20 @@ func (this *FreezeWeapon) createBox(dir mgl32.Vec2, energy float32) *box2d.B2Bod
 	body.SetLinearVelocity(physics2d.ToBox2DDirection(dir.Mul(FREEZE_VELOCITY * energy)))
 	body.SetLinearVelocity(box2d.B2Vec2Add(this.Player.body.GetLinearVelocity(), body.GetLinearVelocity()))
 
-	var spr gohome.Sprite2D
-	var con physics2d.PhysicsConnector2D
-
-	spr.Init("FreezeWeaponBlock")
-	spr.TextureRegion.Max[0] = FREEZE_FRAME_WIDTH
-	spr.Transform.Size[0], spr.Transform.Size[1] = FREEZE_FRAME_WIDTH, FREEZE_FRAME_HEIGHT
-	spr.Transform.Origin = [2]float32{0.5, 0.5}
-	con.Init(spr.Transform, body, &PhysicsMgr)
-
-	gohome.RenderMgr.AddObject(&spr)
-
-	var block WeaponBlock
-	block.Sprite = &spr
-	block.Connector = &con
-	this.blocks = append(this.blocks, block)
-
-	body.SetUserData(&this.blocks[len(this.blocks)-1])
-
-	con.Update()
-
-	return body
+	block := &FreezeBlock{time: FREEZE_TIME}
+	block.Init(BLOCK_FREEZE, this, "FreezeWeaponBlock", body)
+	block.Sprite.TextureRegion.Max[0] = FREEZE_FRAME_WIDTH
+	block.Sprite.Transform.Size[0], block.Sprite.Transform.Size[1] = FREEZE_FRAME_WIDTH, FREEZE_FRAME_HEIGHT
+	block.Sprite.Transform.Origin = [2]float32{0.5, 0.5}
+	this.addBlock(block)
 }
 
 func (this *FreezeWeapon) OnDie() {
-	gohome.UpdateMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(this)
 	gohome.RenderMgr.RemoveObject(&this.NilWeapon)
 }
 
 func (this *FreezeWeapon) Terminate() {
 	this.NilWeapon.Terminate()
-	gohome.UpdateMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(this)
 }
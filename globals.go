@@ -6,6 +6,15 @@ import (
 )
 
 var PhysicsMgr physics2d.PhysicsManager2D
+var Stepper FixedStepper
+var CameraFX CameraEffects
+var MusicMgr MusicManager
+var Mixer AudioMixer
+var Particles ParticleSystem
+var LevelUpdates UpdateGroup
+var Entities EntityRegistry
+var Contacts ContactBus
+var Profile Profiler
 
 const GAME_WIDTH = 1280
 const GAME_HEIGHT = 720
@@ -13,13 +22,17 @@ const GAME_HEIGHT = 720
 const GRAVITY = 200
 const ZOOM = 3
 
-const PLAYER_DEPTH = 1
-const DELETE_RAY_DEPTH = 2
-const WEAPON_DEPTH = 3
-const SPECIAL_DEPTH = 4
-const INVENTORY_DEPTH = 5
-const SCOPE_DEPTH = 6
-const MENU_DEPTH = 7
+const BACKGROUND_DEPTH = 0
+const BASE_DEPTH = 1
+const MAP_DEPTH = BASE_DEPTH
+const PLATFORM_DEPTH = 1
+const PLAYER_DEPTH = 2
+const DELETE_RAY_DEPTH = 3
+const WEAPON_DEPTH = 4
+const SPECIAL_DEPTH = 5
+const INVENTORY_DEPTH = 6
+const SCOPE_DEPTH = 7
+const MENU_DEPTH = 8
 
 const NUM_LEVELS = 9
 
@@ -53,26 +66,15 @@ const CAMERA_SPEED float32 = 0.1
 var CAMERA_OFFSET = [2]float32{0.0, 0.0}
 
 const GROUND_FRICTION float64 = 1.8
-const PLAYER_CATEGORY uint16 = 1 << 0
-const PLAYER_FEET_CATEGORY uint16 = (1 << 1) | PLAYER_CATEGORY
-const GROUND_CATEGORY uint16 = 1 << 2
-const WEAPON_CATEGORY uint16 = 1 << 3
-const ENEMY_CATEGORY uint16 = 1 << 4
-const ENEMY_SENSOR_CATEGORY uint16 = 1 << 5
-const ENEMY_SMALL_LEFT_SENSOR_CATEGORY uint16 = 1<<6 | ENEMY_SENSOR_CATEGORY
-const ENEMY_SMALL_RIGHT_SENSOR_CATEGORY uint16 = 1<<7 | ENEMY_SENSOR_CATEGORY
-const ENEMY_BIG_LEFT_SENSOR_CATEGORY uint16 = 1<<8 | ENEMY_SENSOR_CATEGORY
-const ENEMY_BIG_RIGHT_SENSOR_CATEGORY uint16 = 1<<9 | ENEMY_SENSOR_CATEGORY
-const PLAYER_FEET_SENSOR_CATEGORY uint16 = (1 << 10) | PLAYER_CATEGORY
-const SPIKE_CATEGORY uint16 = 1 << 11
-const BALL_CATEGORY uint16 = 1 << 12
 
 const AI_DISTANCE float32 = CAMERA_BOX_WIDTH * ZOOM / 3.0
 
-const WIN_CONDITION_TARGET = true
-const WIN_CONDITION_ENEMY = false
+const WIN_CONDITION_TARGET uint8 = 0
+const WIN_CONDITION_ENEMY uint8 = 1
+const WIN_CONDITION_EXIT uint8 = 2
 
-var CURRENT_WIN_CONDITION bool
+var CURRENT_WIN_CONDITION uint8
+var ONE_WAY_FREEZE_BLOCKS bool
 
 func LoadResources() {
 	gohome.ResourceMgr.LoadFont("Button", "/usr/share/fonts/truetype/ubuntu/UbuntuMono-R.ttf")
@@ -106,12 +108,21 @@ func LoadResources() {
 	gohome.ResourceMgr.LoadTexture("Continue", "assets/textures/GPPCC14_Continue.png")
 	gohome.ResourceMgr.LoadTexture("Scope", "assets/textures/GPPCC14_Scope.png")
 	gohome.ResourceMgr.LoadSound("Jump", "assets/sounds/GPPCC14_Jump.wav")
-	gohome.ResourceMgr.LoadSound("Shoot", "assets/sounds/GPPCC14_Shoot.wav")
-	gohome.ResourceMgr.LoadSound("Explosion", "assets/sounds/GPPCC14_Explosion.wav")
-	gohome.ResourceMgr.LoadSound("TargetCollect", "assets/sounds/GPPCC14_TargetCollect.wav")
+	LoadSoundVoices("Shoot", "assets/sounds/GPPCC14_Shoot.wav")
+	LoadSoundVoices("Explosion", "assets/sounds/GPPCC14_Explosion.wav")
+	LoadSoundVoices("TargetCollect", "assets/sounds/GPPCC14_TargetCollect.wav")
 	gohome.ResourceMgr.LoadSound("Button", "assets/sounds/GPPCC14_Button.wav")
 	gohome.ResourceMgr.LoadSound("ButtonPressed", "assets/sounds/GPPCC14_ButtonPressed.wav")
+	LoadSoundVoices("Impact", "assets/sounds/GPPCC14_Impact.wav")
+	LoadSoundVoices("FreezeLock", "assets/sounds/GPPCC14_FreezeLock.wav")
+	LoadSoundVoices("Rolling", "assets/sounds/GPPCC14_Rolling.wav")
+	LoadSoundVoices("Hum", "assets/sounds/GPPCC14_Hum.wav")
+	gohome.ResourceMgr.LoadMusic(MUSIC_MENU, "assets/music/GPPCC14_Menu.wav")
+	gohome.ResourceMgr.LoadMusic(MUSIC_LEVEL, "assets/music/GPPCC14_Level.wav")
 	gohome.ResourceMgr.LoadTexture("Options", "assets/textures/GPPCC14_Options.png")
+	gohome.ResourceMgr.LoadTexture("Tileset", "assets/maps/GPPCC14_Tileset.png")
+	gohome.ResourceMgr.LoadTexture("BackgroundHills", "assets/textures/GPPCC14_BackgroundHills.png")
+	gohome.ResourceMgr.LoadTexture("BackgroundClouds", "assets/textures/GPPCC14_BackgroundClouds.png")
 
 	gohome.ResourceMgr.GetTexture("Player").SetFiltering(gohome.FILTERING_NEAREST)
 	gohome.ResourceMgr.GetTexture("DefaultWeapon").SetFiltering(gohome.FILTERING_NEAREST)
@@ -143,4 +154,9 @@ func LoadResources() {
 	gohome.ResourceMgr.GetTexture("Continue").SetFiltering(gohome.FILTERING_NEAREST)
 	gohome.ResourceMgr.GetTexture("Scope").SetFiltering(gohome.FILTERING_NEAREST)
 	gohome.ResourceMgr.GetTexture("Options").SetFiltering(gohome.FILTERING_NEAREST)
+	gohome.ResourceMgr.GetTexture("Tileset").SetFiltering(gohome.FILTERING_NEAREST)
+	gohome.ResourceMgr.GetTexture("BackgroundHills").SetFiltering(gohome.FILTERING_NEAREST)
+	gohome.ResourceMgr.GetTexture("BackgroundClouds").SetFiltering(gohome.FILTERING_NEAREST)
+
+	LoadParticleConfigs()
 }
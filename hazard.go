@@ -0,0 +1,166 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+)
+
+const (
+	SPIKE_BASE_INSET float32 = 2.0
+	SPIKE_TIP_INSET  float32 = 4.0
+	LAVA_HEIGHT      float32 = 0.75
+
+	SPIKE_FIRST_GID uint32 = 85
+	SPIKE_LAST_GID  uint32 = 89
+
+	SAW_TILE          uint32  = 90
+	SAW_SPEED         float32 = 40.0
+	SAW_ANGULAR_SPEED float32 = 360.0
+)
+
+func directionAngle(direction string) float32 {
+	switch direction {
+	case "right":
+		return 90.0
+	case "down":
+		return 180.0
+	case "left":
+		return 270.0
+	default:
+		return 0.0
+	}
+}
+
+func createHazardBody(center mgl32.Vec2, shape box2d.B2ShapeInterface, sensor bool) *box2d.B2Body {
+	bdef := box2d.MakeB2BodyDef()
+	bdef.Type = box2d.B2BodyType.B2_staticBody
+	bdef.Position = physics2d.ToBox2DCoordinates(center)
+
+	fdef := box2d.MakeB2FixtureDef()
+	fdef.Friction = GROUND_FRICTION
+	fdef.IsSensor = sensor
+	fdef.Filter = CollisionFilter(SPIKE_CATEGORY)
+	fdef.Shape = shape
+
+	body := PhysicsMgr.World.CreateBody(&bdef)
+	body.CreateFixtureFromDef(&fdef)
+	return body
+}
+
+func createSpike(pos, size mgl32.Vec2, direction string) {
+	center := pos.Add(size.Mul(0.5))
+	rot := mgl32.Rotate2D(mgl32.DegToRad(directionAngle(direction)))
+	points := [3]mgl32.Vec2{
+		{-size[0]/2.0 + SPIKE_BASE_INSET, size[1] / 2.0},
+		{size[0]/2.0 - SPIKE_BASE_INSET, size[1] / 2.0},
+		{0.0, -size[1]/2.0 + SPIKE_TIP_INSET},
+	}
+
+	var vertices [3]box2d.B2Vec2
+	for i := 0; i < 3; i++ {
+		vertices[i] = physics2d.ToBox2DDirection(rot.Mul2x1(points[i]))
+	}
+	shape := box2d.MakeB2PolygonShape()
+	shape.Set(vertices[:], 3)
+
+	createHazardBody(center, &shape, false)
+}
+
+func createLava(pos, size mgl32.Vec2) {
+	height := size[1] * LAVA_HEIGHT
+	center := pos.Add([2]float32{size[0] / 2.0, size[1] - height/2.0})
+	shape := box2d.MakeB2PolygonShape()
+	shape.SetAsBox(physics2d.ScalarToBox2D(size[0]/2.0), physics2d.ScalarToBox2D(height/2.0))
+
+	createHazardBody(center, &shape, true)
+}
+
+type Sawblade struct {
+	gohome.Sprite2D
+	Body      *box2d.B2Body
+	connector Connector
+
+	Speed        float32
+	AngularSpeed float32
+
+	waypoints  []mgl32.Vec2
+	current    int
+	terminated bool
+}
+
+func createSawTexture(tile uint32, tileWidth, tileHeight int) gohome.RenderTexture {
+	size := tileHeight * 2
+	rt := gohome.Render.CreateRenderTexture("SawbladeTexture", size, size, 1, false, false, false, false)
+	rt.SetFiltering(gohome.FILTERING_NEAREST)
+	prevProj := gohome.RenderMgr.Projection2D
+	rt.SetAsTarget()
+	gohome.RenderMgr.SetProjection2DToTexture(rt)
+	var spr gohome.Sprite2D
+	spr.Init("Tileset")
+	spr.TextureRegion = tileRegion(tile, tileWidth, tileHeight)
+	spr.Transform.Size = [2]float32{float32(size), float32(size)}
+	spr.NotRelativeToCamera = 0
+	gohome.RenderMgr.RenderRenderObject(&spr)
+	rt.UnsetAsTarget()
+	gohome.RenderMgr.Projection2D = prevProj
+
+	return rt
+}
+
+func (this *Sawblade) Init(pos mgl32.Vec2, radius float32, tileWidth, tileHeight int) {
+	this.Sprite2D.InitTexture(createSawTexture(SAW_TILE, tileWidth, tileHeight))
+	this.Flip = gohome.FLIP_VERTICAL
+	this.Transform.Origin = [2]float32{0.5, 0.5}
+	this.Transform.Size = [2]float32{radius * 2.0, radius * 2.0}
+	this.Transform.Position = pos
+	this.Depth = PLATFORM_DEPTH
+
+	bdef := box2d.MakeB2BodyDef()
+	bdef.Type = box2d.B2BodyType.B2_kinematicBody
+	bdef.Position = physics2d.ToBox2DCoordinates(pos)
+
+	fdef := box2d.MakeB2FixtureDef()
+	fdef.Filter = CollisionFilter(SPIKE_CATEGORY)
+	shape := box2d.MakeB2CircleShape()
+	shape.SetRadius(physics2d.ScalarToBox2D(radius))
+	fdef.Shape = &shape
+
+	this.Body = PhysicsMgr.World.CreateBody(&bdef)
+	this.Body.CreateFixtureFromDef(&fdef)
+	this.Body.SetUserData(this)
+	this.Body.SetAngularVelocity(float64(mgl32.DegToRad(this.AngularSpeed)))
+	this.connector.Init(this.Transform, this.Body)
+
+	this.waypoints = append(this.waypoints, pos)
+
+	gohome.RenderMgr.AddObject(this)
+	Stepper.AddController(this)
+	Entities.Add(this)
+	this.terminated = false
+}
+
+func (this *Sawblade) AddWaypoint(offset mgl32.Vec2) (ok bool) {
+	this.waypoints, ok = addWaypoint(this.waypoints, offset)
+	return
+}
+
+func (this *Sawblade) FixedUpdate(delta_time float32) {
+	followPath(this.Body, this.waypoints, &this.current, this.Speed)
+}
+
+func (this *Sawblade) Terminate() {
+	if this.terminated {
+		return
+	}
+
+	PhysicsMgr.World.DestroyBody(this.Body)
+	gohome.RenderMgr.RemoveObject(this)
+	Stepper.RemoveController(this)
+	Entities.Remove(this)
+	this.connector.Terminate()
+	this.Texture.Terminate()
+
+	this.terminated = true
+}
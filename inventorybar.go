@@ -213,6 +213,7 @@ func (this *InventoryBar) renderAmmoTexts() {
 }
 
 func (this *InventoryBar) renderInventory() {
+	defer Profile.End(PROFILE_INVENTORY, Profile.Start())
 	prevProj, _ := this.setRenderTarget()
 	gohome.Render.ClearScreen(colornames.Gray)
 
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+)
+
+const (
+	PLATFORM_PATH    uint8 = 0
+	PLATFORM_ROTATE  uint8 = 1
+	PLATFORM_FALL    uint8 = 2
+	PLATFORM_CRUMBLE uint8 = 3
+
+	PLATFORM_DEFAULT_TILE  uint32  = 16
+	PLATFORM_SPEED         float32 = 50.0
+	PLATFORM_ANGULAR_SPEED float32 = 45.0
+	PLATFORM_DELAY         float32 = 0.5
+	PLATFORM_MIN_DISTANCE  float32 = 2.0
+	PATH_EPSILON           float32 = 0.001
+	PLATFORM_DENSITY       float64 = 1.0
+
+	TILESET_COLUMNS = 12
+	TILESET_SPACING = 1
+)
+
+type LevelPlatform struct {
+	gohome.Sprite2D
+	Body      *box2d.B2Body
+	connector Connector
+
+	Kind         uint8
+	Speed        float32
+	AngularSpeed float32
+	Delay        float32
+	Respawn      float32
+	Bottom       float32
+	Active       bool
+
+	waypoints  []mgl32.Vec2
+	current    int
+	start      box2d.B2Vec2
+	touched    bool
+	players    ContactCounter
+	time       float32
+	broken     bool
+	active     bool
+	terminated bool
+}
+
+func platformKind(name string) uint8 {
+	switch name {
+	case "rotate":
+		return PLATFORM_ROTATE
+	case "fall":
+		return PLATFORM_FALL
+	case "crumble":
+		return PLATFORM_CRUMBLE
+	default:
+		return PLATFORM_PATH
+	}
+}
+
+func tileRegion(id uint32, tileWidth, tileHeight int) (reg gohome.TextureRegion) {
+	x := int(id%TILESET_COLUMNS) * (tileWidth + TILESET_SPACING)
+	y := int(id/TILESET_COLUMNS) * (tileHeight + TILESET_SPACING)
+	reg.Min = [2]float32{float32(x), float32(y)}
+	reg.Max = [2]float32{float32(x + tileWidth), float32(y + tileHeight)}
+	return
+}
+
+func (this *LevelPlatform) Init(pos, size mgl32.Vec2, tile uint32, tileWidth, tileHeight int) {
+	this.Sprite2D.InitTexture(createPlatformTexture(size, tile, tileWidth, tileHeight))
+	this.Flip = gohome.FLIP_VERTICAL
+	this.Transform.Origin = [2]float32{0.5, 0.5}
+	this.Transform.Size = size
+	this.Transform.Position = pos.Add(size.Mul(0.5))
+	this.Depth = PLATFORM_DEPTH
+
+	this.createBody(size)
+	this.connector.Init(this.Transform, this.Body)
+	this.start = this.Body.GetPosition()
+
+	this.waypoints = append(this.waypoints, this.Transform.Position)
+	this.active = this.Active
+
+	LevelUpdates.AddObject(this)
+	gohome.RenderMgr.AddObject(this)
+	Stepper.AddController(this)
+	Entities.Add(this)
+	this.terminated = false
+}
+
+func (this *LevelPlatform) AddWaypoint(offset mgl32.Vec2) (ok bool) {
+	this.waypoints, ok = addWaypoint(this.waypoints, offset)
+	return
+}
+
+// Waypoints too close to the previous one are rejected, a path needs two distinct points
+func addWaypoint(waypoints []mgl32.Vec2, offset mgl32.Vec2) ([]mgl32.Vec2, bool) {
+	wp := waypoints[0].Add(offset)
+	if wp.Sub(waypoints[len(waypoints)-1]).Len() <= PLATFORM_MIN_DISTANCE {
+		return waypoints, false
+	}
+	return append(waypoints, wp), true
+}
+
+func createPlatformTexture(size mgl32.Vec2, tile uint32, tileWidth, tileHeight int) gohome.RenderTexture {
+	rt := gohome.Render.CreateRenderTexture("LevelPlatformTexture", int(size[0]), int(size[1]), 1, false, false, false, false)
+	rt.SetFiltering(gohome.FILTERING_NEAREST)
+	prevProj := gohome.RenderMgr.Projection2D
+	rt.SetAsTarget()
+	gohome.RenderMgr.SetProjection2DToTexture(rt)
+	var spr gohome.Sprite2D
+	spr.Init("Tileset")
+	spr.TextureRegion = tileRegion(tile, tileWidth, tileHeight)
+	spr.Transform.Size = [2]float32{float32(tileWidth), float32(tileHeight)}
+	spr.NotRelativeToCamera = 0
+	for y := 0; y < int(size[1]); y += tileHeight {
+		for x := 0; x < int(size[0]); x += tileWidth {
+			spr.Transform.Position = [2]float32{float32(x), float32(y)}
+			gohome.RenderMgr.RenderRenderObject(&spr)
+		}
+	}
+	rt.UnsetAsTarget()
+	gohome.RenderMgr.Projection2D = prevProj
+
+	return rt
+}
+
+func (this *LevelPlatform) createBody(size mgl32.Vec2) {
+	bdef := box2d.MakeB2BodyDef()
+	bdef.Type = box2d.B2BodyType.B2_kinematicBody
+	bdef.Position = physics2d.ToBox2DCoordinates(this.Transform.Position)
+
+	fdef := box2d.MakeB2FixtureDef()
+	fdef.Friction = GROUND_FRICTION
+	fdef.Density = PLATFORM_DENSITY
+	fdef.Filter = CollisionFilter(GROUND_CATEGORY)
+	shape := box2d.MakeB2PolygonShape()
+	shape.SetAsBox(physics2d.ScalarToBox2D(size[0]/2.0), physics2d.ScalarToBox2D(size[1]/2.0))
+	fdef.Shape = &shape
+
+	this.Body = PhysicsMgr.World.CreateBody(&bdef)
+	this.Body.CreateFixtureFromDef(&fdef)
+	this.Body.SetUserData(this)
+
+	this.players.Init(ContactFilter{Body: this.Body, Other: PLAYER_CATEGORIES})
+}
+
+func followPath(body *box2d.B2Body, waypoints []mgl32.Vec2, current *int, speed float32) {
+	if len(waypoints) < 2 {
+		return
+	}
+
+	pos := physics2d.ToPixelCoordinates(body.GetPosition())
+	rel := waypoints[*current].Sub(pos)
+	if rel.Len() <= PLATFORM_MIN_DISTANCE {
+		*current = (*current + 1) % len(waypoints)
+		rel = waypoints[*current].Sub(pos)
+	}
+	if rel.Len() < PATH_EPSILON {
+		body.SetLinearVelocity(box2d.MakeB2Vec2(0.0, 0.0))
+		return
+	}
+
+	body.SetLinearVelocity(physics2d.ToBox2DDirection(rel.Normalize().Mul(speed)))
+}
+
+func (this *LevelPlatform) updateFall(delta_time float32) {
+	if this.Body.GetType() == box2d.B2BodyType.B2_dynamicBody {
+		if this.Transform.Position.Y() > this.Bottom {
+			this.Terminate()
+		}
+		return
+	}
+
+	if !this.touched {
+		this.touched = this.players.Touching()
+		return
+	}
+
+	this.time += delta_time
+	if this.time >= this.Delay {
+		this.Body.SetType(box2d.B2BodyType.B2_dynamicBody)
+		this.Body.SetAwake(true)
+	}
+}
+
+func (this *LevelPlatform) updateCrumble(delta_time float32) {
+	if this.broken {
+		if this.Respawn <= 0.0 {
+			return
+		}
+		this.time += delta_time
+		if this.time >= this.Respawn {
+			this.Body.SetTransform(this.start, 0.0)
+			this.Body.SetActive(true)
+			this.Visible = true
+			this.broken = false
+			this.touched = false
+			this.time = 0.0
+		}
+		return
+	}
+
+	if !this.touched {
+		this.touched = this.players.Touching()
+		return
+	}
+
+	this.time += delta_time
+	if this.time >= this.Delay {
+		this.Body.SetActive(false)
+		this.Visible = false
+		this.broken = true
+		this.time = 0.0
+	}
+}
+
+func (this *LevelPlatform) SetTriggered(on bool) {
+	this.active = this.Active != on
+	if this.terminated {
+		return
+	}
+	if !this.active && this.Body.GetType() == box2d.B2BodyType.B2_kinematicBody {
+		this.Body.SetLinearVelocity(box2d.B2Vec2{0.0, 0.0})
+		this.Body.SetAngularVelocity(0.0)
+	}
+}
+
+func (this *LevelPlatform) Update(delta_time float32) {
+	if !this.active {
+		return
+	}
+
+	switch this.Kind {
+	case PLATFORM_FALL:
+		this.updateFall(delta_time)
+	case PLATFORM_CRUMBLE:
+		this.updateCrumble(delta_time)
+	}
+}
+
+func (this *LevelPlatform) FixedUpdate(delta_time float32) {
+	if !this.active {
+		return
+	}
+
+	switch this.Kind {
+	case PLATFORM_PATH:
+		followPath(this.Body, this.waypoints, &this.current, this.Speed)
+	case PLATFORM_ROTATE:
+		this.Body.SetAngularVelocity(float64(mgl32.DegToRad(this.AngularSpeed)))
+	}
+}
+
+func (this *LevelPlatform) Terminate() {
+	if this.terminated {
+		return
+	}
+
+	this.players.Terminate()
+	PhysicsMgr.World.DestroyBody(this.Body)
+	LevelUpdates.RemoveObject(this)
+	gohome.RenderMgr.RemoveObject(this)
+	Stepper.RemoveController(this)
+	Entities.Remove(this)
+	this.connector.Terminate()
+	this.Texture.Terminate()
+
+	this.terminated = true
+}
@@ -1,10 +1,11 @@
 package main
 
 import (
-	"github.com/ByteArena/box2d"
 	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
 	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
 	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"github.com/PucklaMotzer09/tmx"
+	"strconv"
 	"strings"
 )
 
@@ -23,13 +24,28 @@ const (
 )
 
 type LevelScene struct {
-	LevelID        uint32
-	Map            gohome.TiledMap
-	Player         Player
-	Enemies        []*Enemy
-	Targets        []*Target
-	targetCollects []*TargetCollect
-	debugInfo      DebugInfo
+	LevelID         uint32
+	MapFile         string
+	Entry           string
+	Carry           []WeaponState
+	Map             gohome.TiledMap
+	Player          Player
+	Enemies         []*Enemy
+	Targets         []*Target
+	camera          CameraController
+	Exits           []*Exit
+	Platforms       []*LevelPlatform
+	Switches        []*Switch
+	Doors           []*Door
+	SpikeTraps      []*SpikeTrap
+	Sawblades       []*Sawblade
+	tilesets        Tilesets
+	backgrounds     []*ParallaxLayer
+	contactListener LevelContactListener
+	triggerables    map[uint32]Triggerable
+	debugInfo       DebugInfo
+	collisionLegend CollisionLegend
+	console         DebugConsole
 
 	debugDraw physics2d.PhysicsDebugDraw2D
 
@@ -55,10 +71,18 @@ func (this *LevelScene) Init() {
 	}
 
 	physics2d.PIXEL_PER_METER = 10.0
-	gohome.ResourceMgr.LoadTMXMap("Level", LEVELS_TMX_MAPS[this.LevelID])
+	if this.MapFile == "" {
+		this.MapFile = LEVELS_TMX_MAPS[this.LevelID]
+	}
+	gohome.ResourceMgr.LoadTMXMap("Level", this.MapFile)
 
 	PhysicsMgr.Init([2]float32{0.0, GRAVITY})
-	gohome.UpdateMgr.AddObject(&PhysicsMgr)
+	LevelUpdates.Init()
+	Entities.Init()
+	Contacts.Init()
+	Stepper.Init()
+	LevelUpdates.AddObject(&Stepper)
+	Particles.Init()
 	this.debugDraw = PhysicsMgr.GetDebugDraw()
 	this.debugDraw.Visible = false
 	gohome.RenderMgr.AddObject(&this.debugDraw)
@@ -71,23 +95,23 @@ func (this *LevelScene) Init() {
 
 func (this *LevelScene) initMap() {
 	this.Map.Init("Level")
+	this.Map.Depth = MAP_DEPTH
 	gohome.RenderMgr.AddObject(&this.Map)
-	groundBodies := PhysicsMgr.LayerToCollision(&this.Map, "Collision")
-	for i := 0; i < len(groundBodies); i++ {
-		b := groundBodies[i]
-		if b == nil {
-			continue
-		}
-		for f := b.GetFixtureList(); f != nil; f = f.GetNext() {
-			filter := f.GetFilterData()
-			filter.CategoryBits = GROUND_CATEGORY
-			filter.MaskBits = 0xffff
-			f.SetFilterData(filter)
-			f.SetFriction(GROUND_FRICTION)
-		}
+	var err error
+	if this.tilesets, err = LoadTilesets(this.MapFile); err != nil {
+		gohome.ErrorMgr.Error("Level", "Tileset", err.Error())
+	}
+	if this.backgrounds, err = LoadParallaxLayers(this.MapFile); err != nil {
+		gohome.ErrorMgr.Error("Level", "Background", err.Error())
+	}
+	for _, b := range this.backgrounds {
+		Entities.Add(b)
 	}
+	PhysicsMgr.World.SetContactListener(&this.contactListener)
+	this.initCollision()
 
 	var playerStart [2]float32
+	this.triggerables = make(map[uint32]Triggerable)
 
 	ls := this.Map.Layers
 	for i := 0; i < len(ls); i++ {
@@ -96,9 +120,39 @@ func (this *LevelScene) initMap() {
 			objs := l.Objects
 			for j := 0; j < len(objs); j++ {
 				o := objs[j]
-				if o.Name == "start" {
+				if o.Name == "start" && this.Entry == "" {
 					playerStart[0] = float32(o.X)
 					playerStart[1] = float32(o.Y)
+				} else if o.Name == "entry" && this.Entry != "" && getPropertyString(o.Properties, "name", "") == this.Entry {
+					playerStart[0] = float32(o.X)
+					playerStart[1] = float32(o.Y)
+				} else if o.Name == "exit" {
+					this.Exits = append(this.Exits, &Exit{
+						Position: [2]float32{float32(o.X), float32(o.Y)},
+						Size:     [2]float32{float32(o.Width), float32(o.Height)},
+						Map:      getPropertyString(o.Properties, "map", ""),
+						Entry:    getPropertyString(o.Properties, "entry", ""),
+					})
+				} else if o.Name == "platform" {
+					this.triggerables[uint32(o.ID)] = this.createPlatform(o)
+				} else if o.Name == "camera" {
+					this.camera.AddZone(o)
+				} else if o.Name == "saw" {
+					this.createSawblade(o)
+				} else if o.Name == "switch" {
+					this.createSwitch(o)
+				} else if o.Name == "door" {
+					door := &Door{Open: getPropertyBool(o.Properties, "open", false)}
+					tile := uint32(getPropertyFloat(o.Properties, "tile", float32(DOOR_TILE)))
+					door.Init([2]float32{float32(o.X), float32(o.Y)}, [2]float32{float32(o.Width), float32(o.Height)}, tile, this.Map.TileWidth, this.Map.TileHeight)
+					this.Doors = append(this.Doors, door)
+					this.triggerables[uint32(o.ID)] = door
+				} else if o.Name == "spikes" {
+					trap := &SpikeTrap{Active: getPropertyBool(o.Properties, "active", true)}
+					tile := uint32(getPropertyFloat(o.Properties, "tile", float32(SPIKE_TRAP_TILE)))
+					trap.Init([2]float32{float32(o.X), float32(o.Y)}, [2]float32{float32(o.Width), float32(o.Height)}, tile, this.Map.TileWidth, this.Map.TileHeight)
+					this.SpikeTraps = append(this.SpikeTraps, trap)
+					this.triggerables[uint32(o.ID)] = trap
 				} else if o.Name == "enemy" {
 					enemy := &Enemy{}
 					enemy.Sprite2D.Init("")
@@ -116,13 +170,21 @@ func (this *LevelScene) initMap() {
 		}
 	}
 
+	this.connectSwitches()
 	this.Player.Init(playerStart, &PhysicsMgr)
+	this.camera.Init(&this.Player, [2]float32{
+		float32(this.Map.Width * this.Map.TileWidth),
+		float32(this.Map.Height * this.Map.TileHeight),
+	}, this.Map.Properties)
 	for i := 0; i < len(this.Enemies); i++ {
 		this.Enemies[i].Init(this.Enemies[i].Transform.Position, &this.Player)
 	}
 
-	CURRENT_WIN_CONDITION = WIN_CONDITION_TARGET
+	CURRENT_WIN_CONDITION = this.defaultWinCondition()
+	ONE_WAY_FREEZE_BLOCKS = false
 	mapprops := this.Map.Properties
+	this.Player.Movement.Load(mapprops)
+	MusicMgr.Play(getPropertyString(mapprops, "music", MUSIC_LEVEL))
 	if mapprops != nil {
 		props := mapprops.Properties
 		for i := 0; i < len(props); i++ {
@@ -132,71 +194,189 @@ func (this *LevelScene) initMap() {
 					CURRENT_WIN_CONDITION = WIN_CONDITION_ENEMY
 				} else if p.Value == "target" {
 					CURRENT_WIN_CONDITION = WIN_CONDITION_TARGET
+				} else if p.Value == "exit" {
+					CURRENT_WIN_CONDITION = WIN_CONDITION_EXIT
 				}
-			} else if strings.Contains(p.Name, "weapon") {
-				if p.Name == "defaultweapon" && p.Value == "true" {
-					this.Player.addWeapon(&DefaultWeapon{})
-				} else if p.Name == "freezeweapon" && p.Value == "true" {
-					this.Player.addWeapon(&FreezeWeapon{})
-				} else if p.Name == "ballweapon" && p.Value == "true" {
-					this.Player.addWeapon(&BallWeapon{})
-				} else if p.Name == "moveweapon" && p.Value == "true" {
-					this.Player.addWeapon(&MoveWeapon{})
-				} else if p.Name == "deleteweapon" && p.Value == "true" {
-					this.Player.addWeapon(&DeleteWeapon{})
+			} else if p.Name == "freeze_oneway" {
+				ONE_WAY_FREEZE_BLOCKS = p.Value == "true"
+			} else if strings.Contains(p.Name, "weapon") && this.Carry == nil {
+				if w := newWeapon(p.Name); w != nil && p.Value == "true" {
+					this.Player.addWeapon(w)
 				}
 			}
 		}
 	}
 
+	for _, state := range this.Carry {
+		if w := newWeapon(state.Name); w != nil {
+			this.Player.addWeapon(w)
+			w.SetAmmo(state.Ammo)
+		}
+	}
+
 	if len(this.Player.weapons) == 0 {
 		this.Player.addWeapon(&DefaultWeapon{})
 	}
 
+	this.initHazards()
+}
+
+// Visits the tiles of the tile layer called layer or of all tile layers if layer is empty
+func (this *LevelScene) eachTile(layer string, callback func(col, row int, tile *TilesetTile)) {
+	this.eachGID(layer, func(col, row int, gid uint32) {
+		if tile := this.tilesets.Tile(gid); tile != nil {
+			callback(col, row, tile)
+		}
+	})
+}
+
+func (this *LevelScene) eachGID(layer string, callback func(col, row int, gid uint32)) {
 	for _, l := range this.Map.Layers {
-		if l.Data != nil {
+		if l.Data != nil && (layer == "" || l.Name == layer) {
 			data := l.Data
 			iter, err := data.Iter()
 			if err != nil {
-				gohome.ErrorMgr.Error("Level", "Spikes", "Couldn't get Iterator")
+				gohome.ErrorMgr.Error("Level", "Tiles", "Couldn't get Iterator")
 				break
 			}
 			for iter.Next() {
-				tile := iter.Get()
-				if tile.GID() >= 85 && tile.GID() <= 89 {
-					counter := iter.GetIndex()
-					pos := mgl32.Vec2{
-						float32((counter % this.Map.Width) * this.Map.TileWidth),
-						float32(((counter - (counter % this.Map.Width)) / this.Map.Width) * this.Map.TileHeight),
-					}
-
-					this.createSpike(pos)
+				gid := uint32(iter.Get().GID())
+				if gid == 0 {
+					continue
 				}
+				counter := iter.GetIndex()
+				callback(counter%this.Map.Width, counter/this.Map.Width, gid)
 			}
 		}
 	}
 }
 
-func (this *LevelScene) createSpike(pos mgl32.Vec2) {
-	w, h := float32(this.Map.TileWidth), float32(this.Map.TileHeight)
+func (this *LevelScene) initHazards() {
+	if this.tilesets == nil || !this.tilesets.HasProperty("hazard") {
+		gohome.ErrorMgr.Error("Level", "Tileset", "No hazard tiles defined for "+this.MapFile+", using the default spike tiles")
+		this.initDefaultSpikes()
+		return
+	}
+
+	this.eachTile("", func(col, row int, tile *TilesetTile) {
+		hazard, ok := tile.Properties["hazard"]
+		if !ok {
+			return
+		}
+		pos := mgl32.Vec2{
+			float32(col * this.Map.TileWidth),
+			float32(row * this.Map.TileHeight),
+		}
+		this.createHazard(pos, hazard, tile.Properties["direction"])
+	})
+}
 
-	bdef := box2d.MakeB2BodyDef()
-	bdef.Type = box2d.B2BodyType.B2_staticBody
-	bdef.Position = physics2d.ToBox2DCoordinates([2]float32{
-		pos[0] + w/2.0,
-		pos[1] + h/2.0,
+func (this *LevelScene) initDefaultSpikes() {
+	this.eachGID("", func(col, row int, gid uint32) {
+		if gid < SPIKE_FIRST_GID || gid > SPIKE_LAST_GID {
+			return
+		}
+		pos := mgl32.Vec2{
+			float32(col * this.Map.TileWidth),
+			float32(row * this.Map.TileHeight),
+		}
+		this.createHazard(pos, "spike", "up")
 	})
+}
+
+func (this *LevelScene) createPlatform(o tmx.Object) *LevelPlatform {
+	p := &LevelPlatform{
+		Kind:         platformKind(getPropertyString(o.Properties, "kind", "path")),
+		Speed:        getPropertyFloat(o.Properties, "speed", PLATFORM_SPEED),
+		AngularSpeed: getPropertyFloat(o.Properties, "angular_speed", PLATFORM_ANGULAR_SPEED),
+		Delay:        getPropertyFloat(o.Properties, "delay", PLATFORM_DELAY),
+		Respawn:      getPropertyFloat(o.Properties, "respawn", 0.0),
+		Bottom:       float32(this.Map.Height * this.Map.TileHeight),
+		Active:       getPropertyBool(o.Properties, "active", true),
+	}
+	tile := uint32(getPropertyFloat(o.Properties, "tile", float32(PLATFORM_DEFAULT_TILE)))
+	p.Init([2]float32{float32(o.X), float32(o.Y)}, [2]float32{float32(o.Width), float32(o.Height)}, tile, this.Map.TileWidth, this.Map.TileHeight)
+	if p.Kind == PLATFORM_PATH {
+		if !p.AddWaypoint([2]float32{
+			getPropertyFloat(o.Properties, "distance_x", 0.0),
+			getPropertyFloat(o.Properties, "distance_y", 0.0),
+		}) {
+			gohome.ErrorMgr.Warning("Level", "Platform", "Path of platform "+strconv.FormatUint(uint64(o.ID), 10)+" needs distance_x or distance_y, it won't move")
+		}
+	}
+	this.Platforms = append(this.Platforms, p)
+	return p
+}
+
+func (this *LevelScene) createSwitch(o tmx.Object) {
+	sw := &Switch{
+		Kind:       switchKind(getPropertyString(o.Properties, "kind", "plate")),
+		TileOff:    uint32(getPropertyFloat(o.Properties, "tile", float32(SWITCH_TILE_OFF))),
+		TileOn:     uint32(getPropertyFloat(o.Properties, "tile_on", float32(SWITCH_TILE_ON))),
+		TileWidth:  this.Map.TileWidth,
+		TileHeight: this.Map.TileHeight,
+	}
+	if o.Properties != nil {
+		for _, p := range o.Properties.Properties {
+			if strings.HasPrefix(p.Name, "target") {
+				id, err := strconv.ParseUint(p.Value, 10, 32)
+				if err != nil {
+					gohome.ErrorMgr.Error("Level", "Switch", "Invalid target "+p.Value)
+					continue
+				}
+				sw.TargetIDs = append(sw.TargetIDs, uint32(id))
+			}
+		}
+	}
+	sw.Init([2]float32{float32(o.X), float32(o.Y)}, [2]float32{float32(o.Width), float32(o.Height)})
+	this.Switches = append(this.Switches, sw)
+}
 
-	body := PhysicsMgr.World.CreateBody(&bdef)
+func (this *LevelScene) connectSwitches() {
+	inputs := make(map[uint32]*TriggerInput)
+	for _, sw := range this.Switches {
+		for _, id := range sw.TargetIDs {
+			t, ok := this.triggerables[id]
+			if !ok {
+				gohome.ErrorMgr.Error("Level", "Switch", "Target "+strconv.FormatUint(uint64(id), 10)+" can't be triggered")
+				continue
+			}
+			input, ok := inputs[id]
+			if !ok {
+				input = &TriggerInput{Target: t}
+				inputs[id] = input
+			}
+			sw.Targets = append(sw.Targets, input)
+		}
+	}
+}
 
-	fdef := box2d.MakeB2FixtureDef()
-	fdef.Friction = GROUND_FRICTION
-	fdef.Filter.CategoryBits = SPIKE_CATEGORY
-	shape := box2d.MakeB2PolygonShape()
-	shape.SetAsBox(physics2d.ScalarToBox2D(w/2.0), physics2d.ScalarToBox2D(h/2.0))
-	fdef.Shape = &shape
+func (this *LevelScene) createHazard(pos mgl32.Vec2, hazard, direction string) {
+	size := mgl32.Vec2{float32(this.Map.TileWidth), float32(this.Map.TileHeight)}
+	switch hazard {
+	case "spike":
+		createSpike(pos, size, direction)
+	case "lava":
+		createLava(pos, size)
+	default:
+		gohome.ErrorMgr.Error("Level", "Hazards", "Unknown hazard "+hazard)
+	}
+}
 
-	body.CreateFixtureFromDef(&fdef)
+func (this *LevelScene) createSawblade(o tmx.Object) {
+	saw := &Sawblade{
+		Speed:        getPropertyFloat(o.Properties, "speed", SAW_SPEED),
+		AngularSpeed: getPropertyFloat(o.Properties, "angular_speed", SAW_ANGULAR_SPEED),
+	}
+	radius := mgl32.Min(float32(o.Width), float32(o.Height)) / 2.0
+	saw.Init([2]float32{float32(o.X) + float32(o.Width)/2.0, float32(o.Y) + float32(o.Height)/2.0}, radius, this.Map.TileWidth, this.Map.TileHeight)
+	if !saw.AddWaypoint([2]float32{
+		getPropertyFloat(o.Properties, "distance_x", 0.0),
+		getPropertyFloat(o.Properties, "distance_y", 0.0),
+	}) {
+		gohome.ErrorMgr.Warning("Level", "Sawblade", "Path of sawblade "+strconv.FormatUint(uint64(o.ID), 10)+" needs distance_x or distance_y, it won't move")
+	}
+	this.Sawblades = append(this.Sawblades, saw)
 }
 
 func (this *LevelScene) initMenus() {
@@ -241,7 +421,9 @@ func (this *LevelScene) initMenus() {
 
 	this.winMenu.Init()
 	this.optionsMenu.Init()
-	this.debugInfo.Init()
+	this.debugInfo.Init(&this.Player)
+	this.collisionLegend.Init()
+	this.console.Init(this)
 	this.levelTitle.Level = uint8(this.LevelID + 1)
 	this.levelTitle.WinCondition = CURRENT_WIN_CONDITION
 	this.levelTitle.Init()
@@ -311,11 +493,11 @@ func (this *LevelScene) initMenu(death bool, inMid bool) {
 	restartBtn.Transform.Size = [2]float32{DEATH_BUTTON_SIZE, DEATH_BUTTON_SIZE}
 	restartBtn.Depth = MENU_DEPTH
 	restartBtn.PressCallback = func(btn *gohome.Button) {
-		gohome.ResourceMgr.GetSound("ButtonPressed").Play(false)
+		Mixer.Play("ButtonPressed", CHANNEL_UI)
 		this.Restart()
 	}
 	restartBtn.EnterCallback = func(btn *gohome.Button) {
-		gohome.ResourceMgr.GetSound("Button").Play(false)
+		Mixer.Play("Button", CHANNEL_UI)
 	}
 
 	backBtn.Init(backPos, "Back")
@@ -323,11 +505,11 @@ func (this *LevelScene) initMenu(death bool, inMid bool) {
 	backBtn.Transform.Size = [2]float32{DEATH_BUTTON_SIZE, DEATH_BUTTON_SIZE}
 	backBtn.Depth = MENU_DEPTH
 	backBtn.PressCallback = func(btn *gohome.Button) {
-		gohome.ResourceMgr.GetSound("ButtonPressed").Play(false)
+		Mixer.Play("ButtonPressed", CHANNEL_UI)
 		gohome.SceneMgr.SwitchScene(&LevelSelectScene{})
 	}
 	backBtn.EnterCallback = func(btn *gohome.Button) {
-		gohome.ResourceMgr.GetSound("Button").Play(false)
+		Mixer.Play("Button", CHANNEL_UI)
 	}
 
 	this.deathBtns[0] = &restartBtn
@@ -337,6 +519,7 @@ func (this *LevelScene) initMenu(death bool, inMid bool) {
 		this.deathText = &gohome.Text2D{}
 		this.deathText.Init(gohome.ButtonFont, int(float32(gohome.ButtonFontSize)*1.5), "Sie sind gestorben")
 		this.deathText.NotRelativeToCamera = 0
+		this.deathText.Depth = BASE_DEPTH
 		this.deathText.Transform.Origin = [2]float32{0.5, 0.5}
 		this.deathText.Transform.Position = deathTextPos
 		gohome.RenderMgr.AddObject(this.deathText)
@@ -348,11 +531,8 @@ func (this *LevelScene) initMenu(death bool, inMid bool) {
 func (this *LevelScene) PauseGame() {
 	this.paused = true
 
-	this.Player.Pause()
-	for _, e := range this.Enemies {
-		e.paused = true
-	}
-	PhysicsMgr.Paused = true
+	LevelUpdates.SetPaused(true)
+	this.camera.paused = true
 	this.pauseBtn.Texture = gohome.ResourceMgr.GetTexture("Resume")
 }
 
@@ -370,18 +550,20 @@ func (this *LevelScene) Resume() {
 	this.menuDirection = UP
 	this.paused = false
 
-	this.Player.Resume()
-	for _, e := range this.Enemies {
-		e.paused = false
-	}
-	PhysicsMgr.Paused = false
+	LevelUpdates.SetPaused(false)
+	this.camera.paused = false
 	this.pauseBtn.Texture = gohome.ResourceMgr.GetTexture("Pause")
 }
 
 func (this *LevelScene) Restart() {
 	prevCamPos := Camera.Position
 	died := this.Player.Died()
-	scn := &LevelScene{LevelID: this.LevelID}
+	scn := &LevelScene{
+		LevelID: this.LevelID,
+		MapFile: this.MapFile,
+		Entry:   this.Entry,
+		Carry:   this.Carry,
+	}
 	gohome.SceneMgr.SwitchScene(scn)
 	if died {
 		scn.initMenu(true, true)
@@ -397,11 +579,13 @@ func (this *LevelScene) ShowWinMenu() {
 	}
 	this.winMenu.direction = DOWN
 	this.PauseGame()
+	MusicMgr.Duck(true)
 }
 
 func (this *LevelScene) HideWinMenu() {
 	this.winMenu.direction = UP
 	this.Resume()
+	MusicMgr.Duck(false)
 }
 
 func (this *LevelScene) updateMenu() {
@@ -465,6 +649,14 @@ func (this *LevelScene) updateMenu() {
 	}
 }
 
+// Rooms without targets are left through their exits instead of being won instantly
+func (this *LevelScene) defaultWinCondition() uint8 {
+	if len(this.Targets) == 0 && len(this.Exits) != 0 {
+		return WIN_CONDITION_EXIT
+	}
+	return WIN_CONDITION_TARGET
+}
+
 func (this *LevelScene) updateWinCondition() {
 	if CURRENT_WIN_CONDITION == WIN_CONDITION_ENEMY {
 		for i := 0; i < len(this.Enemies); i++ {
@@ -492,9 +684,7 @@ func (this *LevelScene) updateWinCondition() {
 					t.Terminate()
 					this.Targets = append(this.Targets[:i], this.Targets[i+1:]...)
 					var tc TargetCollect
-					tc.Init()
-					tc.Transform.Position = t.Transform.Position
-					this.targetCollects = append(this.targetCollects, &tc)
+					tc.Init(t.Transform.Position)
 				}
 			}
 		} else {
@@ -503,6 +693,35 @@ func (this *LevelScene) updateWinCondition() {
 	}
 }
 
+func (this *LevelScene) updateExits() {
+	if this.Player.Died() || this.winMenu.direction == DOWN {
+		return
+	}
+
+	for _, e := range this.Exits {
+		if !e.Contains(&this.Player) {
+			continue
+		}
+		if e.Finishes() {
+			this.ShowWinMenu()
+		} else {
+			this.changeRoom(e)
+		}
+		return
+	}
+}
+
+func (this *LevelScene) changeRoom(e *Exit) {
+	scn := &LevelScene{
+		LevelID: this.LevelID,
+		MapFile: e.Map,
+		Entry:   e.Entry,
+		Carry:   this.Player.WeaponStates(),
+	}
+	gohome.SceneMgr.SwitchScene(scn)
+	this.restarting = true
+}
+
 func (this *LevelScene) handlePlayer() {
 	y := this.Player.Transform.Position.Sub(this.Player.Transform.Size.MulVec(this.Player.Transform.Scale)).Y()
 	my := float32(this.Map.Height * this.Map.TileHeight)
@@ -516,26 +735,12 @@ func (this *LevelScene) handlePlayer() {
 }
 
 func (this *LevelScene) Update(delta_time float32) {
-	if gohome.InputMgr.JustPressed(gohome.KeyF3) {
-		this.debugDraw.Visible = !this.debugDraw.Visible
-	} else if gohome.InputMgr.JustPressed(gohome.KeyR) {
+	if this.console.IsOpen() {
+		return
+	}
+	this.handleDebugKeys()
+	if gohome.InputMgr.JustPressed(gohome.KeyR) {
 		this.Restart()
-	} else if gohome.InputMgr.JustPressed(gohome.KeyU) {
-		gohome.SceneMgr.SwitchScene(&gohome.NilScene{})
-	} else if gohome.InputMgr.JustPressed(gohome.KeyK) {
-		this.menuDirection = !this.menuDirection
-	} else if gohome.InputMgr.JustPressed(gohome.KeyI) {
-		if this.winMenu.direction == UP {
-			this.ShowWinMenu()
-		} else {
-			this.HideWinMenu()
-		}
-	} else if gohome.InputMgr.JustPressed(gohome.KeyT) {
-		if gohome.Framew.CursorShown() {
-			gohome.Framew.CursorDisable()
-		} else {
-			gohome.Framew.CurserShow()
-		}
 	}
 	if this.restarting {
 		return
@@ -549,13 +754,38 @@ func (this *LevelScene) Update(delta_time float32) {
 	}
 	this.updateMenu()
 	this.handlePlayer()
+	this.updateExits()
+	if this.restarting {
+		return
+	}
 	this.updateWinCondition()
 
 	this.debugInfo.Visible = this.debugDraw.Visible
+	this.collisionLegend.Visible = this.debugDraw.Visible
+}
+
+func (this *LevelScene) handleDebugKeys() {
+	if gohome.InputMgr.JustPressed(gohome.KeyF3) {
+		this.debugDraw.Visible = !this.debugDraw.Visible
+	} else if gohome.InputMgr.JustPressed(gohome.KeyF4) {
+		this.toggleDump(DEBUG_CSV_FILE)
+	}
+}
+
+func (this *LevelScene) toggleDump(fileName string) string {
+	if this.debugInfo.Dumping() {
+		this.debugInfo.StopDump()
+		return "Stopped dumping"
+	}
+	this.debugInfo.StartDump(fileName)
+	if !this.debugInfo.Dumping() {
+		return "Couldn't create " + fileName
+	}
+	return "Dumping to " + fileName
 }
 
 func (this *LevelScene) Terminate() {
-	gohome.UpdateMgr.RemoveObject(&PhysicsMgr)
+	LevelUpdates.RemoveObject(&Stepper)
 	gohome.RenderMgr.RemoveObject(&this.Map)
 	gohome.RenderMgr.RemoveObject(&this.debugDraw)
 
@@ -563,8 +793,11 @@ func (this *LevelScene) Terminate() {
 
 	this.terminateMenu()
 	this.winMenu.Terminate()
+	MusicMgr.Duck(false)
 	this.optionsMenu.Terminate()
 	this.debugInfo.Terminate()
+	this.collisionLegend.Terminate()
+	this.console.Terminate()
 	this.levelTitle.Terminate()
 	if this.pauseBtn != nil {
 		this.pauseBtn.Terminate()
@@ -572,16 +805,10 @@ func (this *LevelScene) Terminate() {
 	if this.optionsBtn != nil {
 		this.optionsBtn.Terminate()
 	}
-	for i := 0; i < len(this.Enemies); i++ {
-		this.Enemies[i].Terminate()
-	}
-	for _, t := range this.Targets {
-		t.Terminate()
-	}
-	for _, tc := range this.targetCollects {
-		tc.Terminate()
-	}
-	this.Player.Terminate()
+	Entities.Terminate()
 	this.Map.Terminate()
 	PhysicsMgr.Terminate()
+	Contacts.Terminate()
+	Entities.CheckLeaks()
+	LevelUpdates.Terminate()
 }
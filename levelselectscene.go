@@ -16,12 +16,13 @@ type LevelSelectScene struct {
 	levelBtns    []*gohome.Button
 	targetBtnPos []mgl32.Vec2
 	title        *gohome.Text2D
+	background   LevelSelectBackground
 }
 
 func selectLevel(btn *gohome.Button) {
 	id, _ := strconv.ParseInt(btn.Text, 10, 32)
 	id -= 1
-	gohome.ResourceMgr.GetSound("ButtonPressed").Play(false)
+	Mixer.Play("ButtonPressed", CHANNEL_UI)
 	gohome.SceneMgr.SwitchScene(&LevelScene{LevelID: uint32(id)})
 }
 
@@ -43,13 +44,14 @@ func (this *LevelSelectScene) initButtons() {
 		btn.Text = strconv.FormatInt(int64(i+1), 10)
 		this.targetBtnPos = append(this.targetBtnPos, start.Add([2]float32{x, y}))
 		btn.Init(start.Add([2]float32{x, y}), "LevelButton1")
+		btn.Depth = BASE_DEPTH
 		btn.Transform.Position[1] = -LEVEL_BUTTON_SIZE/2.0 - (maxy - y)
 		btn.Transform.Size = [2]float32{LEVEL_BUTTON_SIZE, LEVEL_BUTTON_SIZE}
 		btn.Transform.Origin = [2]float32{0.5, 0.5}
 		btn.PressCallback = selectLevel
 		btn.EnterCallback = func(button *gohome.Button) {
 			button.Texture = gohome.ResourceMgr.GetTexture("LevelButtonPressed")
-			gohome.ResourceMgr.GetSound("Button").Play(false)
+			Mixer.Play("Button", CHANNEL_UI)
 		}
 		btn.LeaveCallback = func(button *gohome.Button) {
 			button.Texture = gohome.ResourceMgr.GetTexture("LevelButton1")
@@ -73,6 +75,7 @@ func (this *LevelSelectScene) initTitle() {
 	this.title.Transform.Origin = [2]float32{0.5, 0.5}
 	this.title.Transform.Position = [2]float32{gohome.Render.GetNativeResolution().X()/2.0 + 10.0, -LEVEL_BUTTON_SIZE/2.0 - maxy - (start[1] - 100.0)}
 	this.title.NotRelativeToCamera = 0
+	this.title.Depth = BASE_DEPTH
 	gohome.RenderMgr.AddObject(this.title)
 }
 
@@ -90,6 +93,8 @@ func (this *LevelSelectScene) updateTitle() {
 }
 
 func (this *LevelSelectScene) Init() {
+	this.background.Init()
+	MusicMgr.Play(MUSIC_MENU)
 	this.initButtons()
 	this.initTitle()
 }
@@ -105,4 +110,5 @@ func (this *LevelSelectScene) Terminate() {
 	}
 	gohome.RenderMgr.RemoveObject(this.title)
 	this.title.Terminate()
+	this.background.Terminate()
 }
@@ -11,8 +11,31 @@ const (
 	VOLUME_SLIDER_LONG_WIDTH  float32 = 300.0
 	VOLUME_SLIDER_LONG_HEIGHT float32 = 25.0
 	VOLUME_SLIDER_STEP_SIZE   float32 = 0.1
+
+	EFFECTS_BUTTON_WIDTH  float32 = VOLUME_SLIDER_LONG_WIDTH
+	EFFECTS_BUTTON_HEIGHT float32 = 40.0
+
+	MUTE_BUTTON_WIDTH   float32 = 100.0
+	MUTE_BUTTON_PADDING float32 = 20.0
+
+	OPTIONS_MENU_WIDTH   float32 = VOLUME_SLIDER_LONG_WIDTH + MUTE_BUTTON_PADDING + MUTE_BUTTON_WIDTH
+	OPTIONS_MENU_SPACING float32 = 90.0
 )
 
+func effectsButtonText() string {
+	if CAMERA_EFFECTS {
+		return "Kameraeffekte: An"
+	}
+	return "Kameraeffekte: Aus"
+}
+
+func muteButtonText(muted bool) string {
+	if muted {
+		return "Stumm"
+	}
+	return "An"
+}
+
 type WinMenu struct {
 	backBtn     gohome.Button
 	continueBtn gohome.Button
@@ -30,11 +53,11 @@ func (this *WinMenu) Init() {
 	this.backBtn.Transform.Size = [2]float32{DEATH_BUTTON_SIZE, DEATH_BUTTON_SIZE}
 	this.backBtn.Transform.Origin = [2]float32{0.5, 0.5}
 	this.backBtn.PressCallback = func(btn *gohome.Button) {
-		gohome.ResourceMgr.GetSound("ButtonPressed").Play(false)
+		Mixer.Play("ButtonPressed", CHANNEL_UI)
 		gohome.SceneMgr.SwitchScene(&LevelSelectScene{})
 	}
 	this.backBtn.EnterCallback = func(btn *gohome.Button) {
-		gohome.ResourceMgr.GetSound("Button").Play(false)
+		Mixer.Play("Button", CHANNEL_UI)
 	}
 	this.backBtn.Depth = MENU_DEPTH
 
@@ -45,11 +68,11 @@ func (this *WinMenu) Init() {
 	this.continueBtn.Transform.Size = [2]float32{DEATH_BUTTON_SIZE, DEATH_BUTTON_SIZE}
 	this.continueBtn.Transform.Origin = [2]float32{0.5, 0.5}
 	this.continueBtn.PressCallback = func(btn *gohome.Button) {
-		gohome.ResourceMgr.GetSound("ButtonPressed").Play(false)
+		Mixer.Play("ButtonPressed", CHANNEL_UI)
 		gohome.SceneMgr.SwitchScene(&LevelScene{LevelID: gohome.SceneMgr.GetCurrentScene().(*LevelScene).LevelID + 1})
 	}
 	this.continueBtn.EnterCallback = func(btn *gohome.Button) {
-		gohome.ResourceMgr.GetSound("Button").Play(false)
+		Mixer.Play("Button", CHANNEL_UI)
 	}
 	this.continueBtn.Depth = MENU_DEPTH
 
@@ -96,70 +119,158 @@ func (this *WinMenu) Terminate() {
 	gohome.UpdateMgr.RemoveObject(this)
 }
 
-type OptionsMenu struct {
-	text         gohome.Text2D
-	volumeSlider gohome.Slider
-	direction    bool
-}
+type VolumeControl struct {
+	Channel AudioChannel
 
-func (this *OptionsMenu) Init() {
-	mid := gohome.Render.GetNativeResolution().Div(2.0)
-
-	gohome.UpdateMgr.AddObject(this)
+	text    gohome.Text2D
+	slider  gohome.Slider
+	muteBtn gohome.Button
+	changed bool
+}
 
-	this.volumeSlider.Init(mid.Sub([2]float32{VOLUME_SLIDER_LONG_WIDTH / 2.0, mid.Y() + VOLUME_SLIDER_LONG_HEIGHT + (VOLUME_SLIDER_CIRCLE_SIZE/2.0 - VOLUME_SLIDER_LONG_HEIGHT/2.0) + VOLUME_SLIDER_CIRCLE_SIZE}), "", "")
-	this.volumeSlider.Circle.Transform.Size = [2]float32{VOLUME_SLIDER_CIRCLE_SIZE, VOLUME_SLIDER_CIRCLE_SIZE}
-	this.volumeSlider.Long.Transform.Size = [2]float32{VOLUME_SLIDER_LONG_WIDTH, VOLUME_SLIDER_LONG_HEIGHT}
-	this.volumeSlider.Circle.Depth = MENU_DEPTH
-	this.volumeSlider.Long.Depth = MENU_DEPTH
-	this.volumeSlider.ValueChangedCallback = func(sld *gohome.Slider) {
-		gohome.AudioMgr.SetVolume(sld.Value)
+func (this *VolumeControl) Init(channel AudioChannel, pos mgl32.Vec2) {
+	this.Channel = channel
+
+	this.slider.Init(pos, "", "")
+	this.slider.Circle.Transform.Size = [2]float32{VOLUME_SLIDER_CIRCLE_SIZE, VOLUME_SLIDER_CIRCLE_SIZE}
+	this.slider.Long.Transform.Size = [2]float32{VOLUME_SLIDER_LONG_WIDTH, VOLUME_SLIDER_LONG_HEIGHT}
+	this.slider.Circle.Depth = MENU_DEPTH
+	this.slider.Long.Depth = MENU_DEPTH
+	this.slider.ValueChangedCallback = func(sld *gohome.Slider) {
+		Mixer.SetVolume(this.Channel, sld.Value)
+		this.changed = true
+	}
+	this.slider.Value = Mixer.Volumes[channel]
+	this.slider.StepSize = VOLUME_SLIDER_STEP_SIZE
+
+	this.muteBtn.Text = muteButtonText(Mixer.Muted[channel])
+	this.muteBtn.Init(pos.Add(this.muteOffset()), "")
+	this.muteBtn.Transform.Size = [2]float32{MUTE_BUTTON_WIDTH, EFFECTS_BUTTON_HEIGHT}
+	this.muteBtn.Transform.Origin = [2]float32{0.5, 0.5}
+	this.muteBtn.Depth = MENU_DEPTH
+	this.muteBtn.PressCallback = func(btn *gohome.Button) {
+		Mixer.SetMuted(this.Channel, !Mixer.Muted[this.Channel])
+		Mixer.Play("ButtonPressed", CHANNEL_UI)
+		btn.Text = muteButtonText(Mixer.Muted[this.Channel])
+		this.changed = true
 	}
-	this.volumeSlider.Value = gohome.AudioMgr.GetVolume()
-	this.volumeSlider.StepSize = VOLUME_SLIDER_STEP_SIZE
 
-	this.text.Init(gohome.ButtonFont, gohome.ButtonFontSize*2.0, "Lautstärke")
+	this.text.Init(gohome.ButtonFont, gohome.ButtonFontSize*2.0, channel.Name())
 	this.text.NotRelativeToCamera = 0
+	this.text.Depth = MENU_DEPTH
 	this.text.Transform.Origin = [2]float32{0.5, 0.5}
-	this.text.Transform.Position = mid.Sub([2]float32{0.0, mid.Y() + VOLUME_SLIDER_LONG_HEIGHT + this.text.Transform.Size[1]*this.text.Transform.Scale[1]})
+	this.text.Transform.Position = pos.Add(this.textOffset())
 
 	gohome.RenderMgr.AddObject(&this.text)
+}
+
+func (this *VolumeControl) textOffset() mgl32.Vec2 {
+	return [2]float32{165.0, -(VOLUME_SLIDER_LONG_HEIGHT + this.text.Transform.Size[1]*this.text.Transform.Scale[1])}
+}
+
+func (this *VolumeControl) muteOffset() mgl32.Vec2 {
+	return [2]float32{VOLUME_SLIDER_LONG_WIDTH + MUTE_BUTTON_PADDING + MUTE_BUTTON_WIDTH/2.0, VOLUME_SLIDER_LONG_HEIGHT / 2.0}
+}
+
+func (this *VolumeControl) Update(target mgl32.Vec2) {
+	this.slider.Long.Transform.Position = this.slider.Long.Transform.Position.Add(target.Sub(this.slider.Long.Transform.Position).Mul(0.07))
+
+	target1 := target.Add(this.textOffset())
+	this.text.Transform.Position = this.text.Transform.Position.Add(target1.Sub(this.text.Transform.Position).Mul(0.06))
+
+	target2 := target.Add(this.muteOffset())
+	this.muteBtn.Transform.Position = this.muteBtn.Transform.Position.Add(target2.Sub(this.muteBtn.Transform.Position).Mul(0.07))
+}
+
+func (this *VolumeControl) Terminate() {
+	this.slider.Terminate()
+	this.slider.Long.Terminate()
+	this.slider.Circle.Terminate()
+	this.muteBtn.Terminate()
+	this.text.Terminate()
+	gohome.RenderMgr.RemoveObject(&this.text)
+}
+
+type OptionsMenu struct {
+	volumes    [NUM_AUDIO_CHANNELS]VolumeControl
+	effectsBtn gohome.Button
+	direction  bool
+}
 
+func (this *OptionsMenu) targets() (mgl32.Vec2, mgl32.Vec2) {
+	mid := gohome.Render.GetNativeResolution().Div(2.0)
+	if this.direction == UP {
+		return mid.Sub([2]float32{OPTIONS_MENU_WIDTH / 2.0, mid.Y() + VOLUME_SLIDER_LONG_HEIGHT + (VOLUME_SLIDER_CIRCLE_SIZE/2.0 - VOLUME_SLIDER_LONG_HEIGHT/2.0) + VOLUME_SLIDER_CIRCLE_SIZE}), [2]float32{0.0, -OPTIONS_MENU_SPACING}
+	}
+	return mid.Sub([2]float32{OPTIONS_MENU_WIDTH / 2.0, OPTIONS_MENU_SPACING * 1.5}), [2]float32{0.0, OPTIONS_MENU_SPACING}
+}
+
+func (this *OptionsMenu) effectsTarget(target, spacing mgl32.Vec2) mgl32.Vec2 {
+	return target.Add(spacing.Mul(float32(NUM_AUDIO_CHANNELS))).Add([2]float32{OPTIONS_MENU_WIDTH / 2.0, VOLUME_SLIDER_LONG_HEIGHT / 2.0})
+}
+
+func (this *OptionsMenu) Init() {
 	this.direction = UP
+	target, spacing := this.targets()
+
+	gohome.UpdateMgr.AddObject(this)
+
+	for i := 0; i < len(this.volumes); i++ {
+		this.volumes[i].Init(AudioChannel(i), target.Add(spacing.Mul(float32(i))))
+	}
 
+	this.effectsBtn.Text = effectsButtonText()
+	this.effectsBtn.Init(this.effectsTarget(target, spacing), "")
+	this.effectsBtn.Transform.Size = [2]float32{EFFECTS_BUTTON_WIDTH, EFFECTS_BUTTON_HEIGHT}
+	this.effectsBtn.Transform.Origin = [2]float32{0.5, 0.5}
+	this.effectsBtn.Depth = MENU_DEPTH
+	this.effectsBtn.PressCallback = func(btn *gohome.Button) {
+		Mixer.Play("ButtonPressed", CHANNEL_UI)
+		CAMERA_EFFECTS = !CAMERA_EFFECTS
+		btn.Text = effectsButtonText()
+	}
 }
 
 func (this *OptionsMenu) Update(delta_time float32) {
-	var target mgl32.Vec2
-	mid := gohome.Render.GetNativeResolution().Div(2.0)
+	target, spacing := this.targets()
 
-	if this.direction == UP {
-		target = mid.Sub([2]float32{VOLUME_SLIDER_LONG_WIDTH / 2.0, mid.Y() + VOLUME_SLIDER_LONG_HEIGHT + (VOLUME_SLIDER_CIRCLE_SIZE/2.0 - VOLUME_SLIDER_LONG_HEIGHT/2.0) + VOLUME_SLIDER_CIRCLE_SIZE})
-	} else {
-		target = mid.Sub([2]float32{VOLUME_SLIDER_LONG_WIDTH / 2.0, -VOLUME_SLIDER_LONG_HEIGHT / 2.0})
+	for i := 0; i < len(this.volumes); i++ {
+		this.volumes[i].Update(target.Add(spacing.Mul(float32(i))))
 	}
 
-	this.volumeSlider.Long.Transform.Position = this.volumeSlider.Long.Transform.Position.Add(target.Sub(this.volumeSlider.Long.Transform.Position).Mul(0.07))
+	target2 := this.effectsTarget(target, spacing)
+	this.effectsBtn.Transform.Position = this.effectsBtn.Transform.Position.Add(target2.Sub(this.effectsBtn.Transform.Position).Mul(0.07))
 
-	target1 := target.Sub([2]float32{-165.0, VOLUME_SLIDER_LONG_HEIGHT + this.text.Transform.Size[1]*this.text.Transform.Scale[1]})
+	if this.direction == UP {
+		this.saveSettings()
+	}
+}
 
-	this.text.Transform.Position = this.text.Transform.Position.Add(target1.Sub(this.text.Transform.Position).Mul(0.06))
+func (this *OptionsMenu) saveSettings() {
+	changed := false
+	for i := 0; i < len(this.volumes); i++ {
+		changed = changed || this.volumes[i].changed
+		this.volumes[i].changed = false
+	}
+	if changed {
+		Mixer.Save()
+	}
 }
 
 func (this *OptionsMenu) Terminate() {
-	this.volumeSlider.Terminate()
-	this.volumeSlider.Long.Terminate()
-	this.volumeSlider.Circle.Terminate()
+	this.saveSettings()
+	for i := 0; i < len(this.volumes); i++ {
+		this.volumes[i].Terminate()
+	}
+	this.effectsBtn.Terminate()
 	gohome.UpdateMgr.RemoveObject(this)
-	this.text.Terminate()
-	gohome.RenderMgr.RemoveObject(&this.text)
 }
 
 type LevelTitle struct {
 	gohome.Text2D
 
 	Level        uint8
-	WinCondition bool
+	WinCondition uint8
 
 	direction bool
 }
@@ -208,6 +319,8 @@ func (this *LevelTitle) winConditionToString() string {
 		return "Sammle alle Flaggen"
 	case WIN_CONDITION_ENEMY:
 		return "Besiege alle Gegner"
+	case WIN_CONDITION_EXIT:
+		return "Finde den Ausgang"
 	default:
 		return "Schließe den Level ab"
 	}
@@ -219,6 +332,8 @@ func (this *LevelTitle) WCS() string {
 		return "      "
 	case WIN_CONDITION_ENEMY:
 		return "      "
+	case WIN_CONDITION_EXIT:
+		return "     "
 	default:
 		return "       "
 	}
@@ -33,7 +33,7 @@ const (
 type MoveWeapon struct {
 	NilWeapon
 
-	platforms []*MovePlatform
+	hum LoopSound
 }
 
 func (this *MoveWeapon) OnAdd(p *Player) {
@@ -42,25 +42,18 @@ func (this *MoveWeapon) OnAdd(p *Player) {
 
 	this.NilWeapon.OnAdd(p)
 	this.Ammo = MOVE_WEAPON_AMMO
+	this.hum = LoopSound{Name: "Hum", Channel: CHANNEL_SFX}
 
-	gohome.UpdateMgr.AddObject(this)
+	LevelUpdates.AddObject(this)
 }
 
 func (this *MoveWeapon) GetInventoryTexture() gohome.Texture {
 	return gohome.ResourceMgr.GetTexture("MoveWeaponInv")
 }
 
-func (this *MoveWeapon) Pause() {
-	this.NilWeapon.Pause()
-	for _, p := range this.platforms {
-		p.paused = true
-	}
-}
-
-func (this *MoveWeapon) Resume() {
-	this.NilWeapon.Resume()
-	for _, p := range this.platforms {
-		p.paused = false
+func (this *MoveWeapon) SetPaused(paused bool) {
+	if paused {
+		this.hum.Stop()
 	}
 }
 
@@ -69,52 +62,31 @@ func (this *MoveWeapon) Use(target mgl32.Vec2, energy float32) {
 	bdir := dir.X() >= 0.0
 	body := this.createBox(dir, energy)
 
-	this.platforms = append(this.platforms, &MovePlatform{
-		WeaponBlock{},
-		body,
-		0.0,
-		[2]float32{0.0, 0.0},
-		false,
-		box2d.B2Vec2{0.0, 0.0},
-		box2d.B2Vec2{0.0, 0.0},
-		bdir,
-		this.Player,
-		gohome.Tweenset{},
-		gohome.Tweenset{},
-		false,
-	})
-
-	var spr gohome.Sprite2D
-	var con physics2d.PhysicsConnector2D
-
-	spr.Init("MoveWeaponBlock")
+	p := &MovePlatform{
+		Direction: bdir,
+		Player:    this.Player,
+	}
+	p.Init(BLOCK_MOVE, this, "MoveWeaponBlock", body)
+	spr := &p.Sprite
 	spr.TextureRegion.Max[0] = float32(spr.Texture.GetWidth()) / 3.0
 	spr.TextureRegion.Min[1] = (float32(spr.Texture.GetHeight()) / 3.0) * 2.0
 	spr.Transform.Size[0], spr.Transform.Size[1] = float32(spr.Texture.GetWidth())/3.0, float32(spr.Texture.GetHeight())/3.0
 	spr.Transform.Origin = [2]float32{0.5, 0.5}
-	con.Init(spr.Transform, body, &PhysicsMgr)
-
-	gohome.RenderMgr.AddObject(&spr)
 
-	p := this.platforms[len(this.platforms)-1]
-	p.Sprite = &spr
-	p.Connector = &con
 	p.rightAnim = gohome.SpriteAnimation2DOffset(spr.Texture, 3, 1, 0, 0, 0, spr.Texture.GetHeight()/3*2, MOVE_WEAPON_FRAME_TIME)
 	p.leftAnim = gohome.SpriteAnimation2DOffset(spr.Texture, 3, 1, 0, spr.Texture.GetHeight()/3, 0, spr.Texture.GetHeight()/3, MOVE_WEAPON_FRAME_TIME)
-	p.rightAnim.SetParent(&spr)
-	p.leftAnim.SetParent(&spr)
+	p.rightAnim.SetParent(spr)
+	p.leftAnim.SetParent(spr)
 	p.rightAnim.Loop = true
 	p.leftAnim.Loop = true
 	p.rightAnim.Stop()
 	p.leftAnim.Stop()
 
-	gohome.UpdateMgr.AddObject(p)
-	gohome.UpdateMgr.AddObject(&p.rightAnim)
-	gohome.UpdateMgr.AddObject(&p.leftAnim)
-
-	body.SetUserData(p)
-
-	con.Update()
+	LevelUpdates.AddObject(p)
+	Stepper.AddController(p)
+	LevelUpdates.AddObject(&p.rightAnim)
+	LevelUpdates.AddObject(&p.leftAnim)
+	this.addBlock(p)
 
 	this.Ammo--
 }
@@ -126,6 +98,18 @@ func (this *MoveWeapon) Update(delta_time float32) {
 		off[0] = -off[0]
 	}
 	this.Transform.Position = this.Player.Transform.Position.Add(this.Player.GetWeaponOffset()).Add(off)
+
+	this.updateHumSound()
+}
+
+func (this *MoveWeapon) updateHumSound() {
+	var volume float32
+	for _, block := range this.blocks {
+		if p := block.(*MovePlatform); p.IsMoving {
+			volume = mgl32.Max(volume, attenuation(p.Sprite.Transform.Position))
+		}
+	}
+	this.hum.SetVolume(volume * HUM_VOLUME)
 }
 
 func (this *MoveWeapon) createBox(dir mgl32.Vec2, energy float32) *box2d.B2Body {
@@ -140,7 +124,7 @@ func (this *MoveWeapon) createBox(dir mgl32.Vec2, energy float32) *box2d.B2Body
 	fdef.Friction = MOVE_WEAPON_FRICTION
 	fdef.Density = 1.0 / (physics2d.ScalarToBox2D(MOVE_WEAPON_WIDTH) * physics2d.ScalarToBox2D(MOVE_WEAPON_HEIGHT)) * MOVE_WEAPON_WEIGHT
 	fdef.Restitution = MOVE_WEAPON_RESTITUTION
-	fdef.Filter.CategoryBits = WEAPON_CATEGORY
+	fdef.Filter = CollisionFilter(WEAPON_CATEGORY)
 	shape := box2d.MakeB2PolygonShape()
 	shape.SetAsBox(physics2d.ScalarToBox2D(size[0])/2.0, physics2d.ScalarToBox2D(size[1])/2.0)
 	fdef.Shape = &shape
@@ -154,16 +138,15 @@ func (this *MoveWeapon) createBox(dir mgl32.Vec2, energy float32) *box2d.B2Body
 }
 
 func (this *MoveWeapon) OnDie() {
-	gohome.UpdateMgr.RemoveObject(this)
+	this.hum.Stop()
+	LevelUpdates.RemoveObject(this)
 	gohome.RenderMgr.RemoveObject(&this.NilWeapon)
 }
 
 func (this *MoveWeapon) Terminate() {
+	this.hum.Stop()
 	this.NilWeapon.Terminate()
-	for _, p := range this.platforms {
-		p.Terminate()
-	}
-	gohome.UpdateMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(this)
 }
 
 const (
@@ -173,7 +156,6 @@ const (
 
 type MovePlatform struct {
 	WeaponBlock
-	Body               *box2d.B2Body
 	Time               float32
 	PrevPosition       mgl32.Vec2
 	IsMoving           bool
@@ -184,7 +166,6 @@ type MovePlatform struct {
 
 	rightAnim gohome.Tweenset
 	leftAnim  gohome.Tweenset
-	paused    bool
 }
 
 func (this *MovePlatform) HoldRotation() {
@@ -285,25 +266,28 @@ func (this *MovePlatform) startMoving() {
 }
 
 func (this *MovePlatform) Update(delta_time float32) {
-	if this.paused {
-		return
-	}
-
 	if !this.IsMoving {
 		this.Time += delta_time
 		if this.Time > MOVE_WEAPON_TIME {
 			this.startMoving()
 		}
-	} else {
-		this.Move()
-		this.HoldRotation()
-		this.HoldPosition()
 	}
 }
 
+func (this *MovePlatform) FixedUpdate(delta_time float32) {
+	if !this.IsMoving {
+		return
+	}
+
+	this.Move()
+	this.HoldRotation()
+	this.HoldPosition()
+}
+
 func (this *MovePlatform) Terminate() {
 	this.WeaponBlock.Terminate()
-	gohome.UpdateMgr.RemoveObject(&this.rightAnim)
-	gohome.UpdateMgr.RemoveObject(&this.leftAnim)
-	gohome.UpdateMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(&this.rightAnim)
+	LevelUpdates.RemoveObject(&this.leftAnim)
+	LevelUpdates.RemoveObject(this)
+	Stepper.RemoveController(this)
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+)
+
+const (
+	MUSIC_FADE_TIME   float32 = 1.5
+	MUSIC_DUCK_TIME   float32 = 0.5
+	MUSIC_DUCK_VOLUME float32 = 0.3
+
+	MUSIC_MENU  = "MenuMusic"
+	MUSIC_LEVEL = "LevelMusic"
+)
+
+type MusicManager struct {
+	current     gohome.Music
+	previous    gohome.Music
+	currentName string
+	fade        float32
+	duck        float32
+	ducked      bool
+}
+
+func (this *MusicManager) Init() {
+	this.duck = 1.0
+	this.fade = 1.0
+
+	gohome.UpdateMgr.AddObject(this)
+}
+
+func (this *MusicManager) Play(name string) {
+	if name == this.currentName {
+		return
+	}
+	music := gohome.ResourceMgr.GetMusic(name)
+	if music == nil {
+		gohome.ErrorMgr.Warning("Music", name, "Couldn't find music")
+		return
+	}
+
+	if this.previous != nil {
+		this.previous.Stop()
+	}
+	this.previous = this.current
+	this.current = music
+	this.currentName = name
+	this.fade = 0.0
+
+	this.current.SetVolume(0.0)
+	this.current.Play(true)
+}
+
+func (this *MusicManager) Duck(duck bool) {
+	this.ducked = duck
+}
+
+func (this *MusicManager) Update(delta_time float32) {
+	this.fade = mgl32.Clamp(this.fade+delta_time/MUSIC_FADE_TIME, 0.0, 1.0)
+
+	target := float32(1.0)
+	if this.ducked {
+		target = MUSIC_DUCK_VOLUME
+	}
+	step := (1.0 - MUSIC_DUCK_VOLUME) * delta_time / MUSIC_DUCK_TIME
+	if this.duck < target {
+		this.duck = mgl32.Clamp(this.duck+step, MUSIC_DUCK_VOLUME, target)
+	} else if this.duck > target {
+		this.duck = mgl32.Clamp(this.duck-step, target, 1.0)
+	}
+
+	volume := Mixer.Volume(CHANNEL_MUSIC) * this.duck
+	if this.current != nil {
+		this.current.SetVolume(volume * this.fade)
+	}
+	if this.previous != nil {
+		if this.fade >= 1.0 {
+			this.previous.Stop()
+			this.previous = nil
+		} else {
+			this.previous.SetVolume(volume * (1.0 - this.fade))
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	BACKGROUND_CLOUD_SPEED float32 = 15.0
+	BACKGROUND_SCALE       float32 = ZOOM
+)
+
+type imageLayerXML struct {
+	Name       string            `xml:"name,attr"`
+	OffsetX    float32           `xml:"offsetx,attr"`
+	OffsetY    float32           `xml:"offsety,attr"`
+	Visible    string            `xml:"visible,attr"`
+	Source     string            `xml:"image>source,attr"`
+	Properties []tilesetProperty `xml:"properties>property"`
+}
+
+type imageLayerMapXML struct {
+	ImageLayers []imageLayerXML `xml:"imagelayer"`
+}
+
+var backgroundTextures = make(map[string]gohome.Texture)
+
+func loadBackgroundTexture(path string) gohome.Texture {
+	if tex, ok := backgroundTextures[path]; ok {
+		return tex
+	}
+	gohome.ResourceMgr.LoadTexture(path, path)
+	tex := gohome.ResourceMgr.GetTexture(path)
+	if tex != nil {
+		tex.SetFiltering(gohome.FILTERING_NEAREST)
+		backgroundTextures[path] = tex
+	}
+	return tex
+}
+
+type ParallaxLayer struct {
+	gohome.Sprite2D
+	Offset   mgl32.Vec2
+	Parallax mgl32.Vec2
+}
+
+func (this *ParallaxLayer) Init(tex gohome.Texture, offset, parallax mgl32.Vec2) {
+	this.Sprite2D.InitTexture(tex)
+	this.Offset = offset
+	this.Parallax = parallax
+	this.Depth = BACKGROUND_DEPTH
+	this.Update(0.0)
+
+	gohome.UpdateMgr.AddObject(this)
+	gohome.RenderMgr.AddObject(this)
+}
+
+func (this *ParallaxLayer) Update(delta_time float32) {
+	this.Transform.Position = [2]float32{
+		this.Offset.X() + Camera.Position.X()*(1.0-this.Parallax.X()),
+		this.Offset.Y() + Camera.Position.Y()*(1.0-this.Parallax.Y()),
+	}
+}
+
+func (this *ParallaxLayer) Terminate() {
+	gohome.UpdateMgr.RemoveObject(this)
+	gohome.RenderMgr.RemoveObject(this)
+	Entities.Remove(this)
+}
+
+func LoadParallaxLayers(mapFile string) ([]*ParallaxLayer, error) {
+	var mf imageLayerMapXML
+	if err := readXML(MAPS_PATH+mapFile, &mf); err != nil {
+		return nil, err
+	}
+
+	var layers []*ParallaxLayer
+	for _, l := range mf.ImageLayers {
+		if l.Source == "" || l.Visible == "0" {
+			continue
+		}
+		props := make(map[string]string)
+		for _, p := range l.Properties {
+			props[p.Name] = p.Value
+		}
+		px := parseFloat(props["parallax"], 1.0)
+		py := parseFloat(props["parallax_y"], px)
+
+		path := filepath.Join(MAPS_PATH, filepath.Dir(mapFile), l.Source)
+		tex := loadBackgroundTexture(path)
+		if tex == nil {
+			gohome.ErrorMgr.Error("Level", "Background", "Couldn't load "+path)
+			continue
+		}
+		layer := &ParallaxLayer{}
+		layer.Init(tex, [2]float32{l.OffsetX, l.OffsetY}, [2]float32{px, py})
+		layers = append(layers, layer)
+	}
+	return layers, nil
+}
+
+func parseFloat(str string, def float32) float32 {
+	if f, err := strconv.ParseFloat(str, 32); err == nil {
+		return float32(f)
+	}
+	return def
+}
+
+type LevelSelectBackground struct {
+	clouds [2]gohome.Sprite2D
+	hills  gohome.Sprite2D
+	scroll float32
+}
+
+func (this *LevelSelectBackground) Init() {
+	res := gohome.Render.GetNativeResolution()
+
+	this.hills.Init("BackgroundHills")
+	this.hills.Transform.Scale = [2]float32{BACKGROUND_SCALE, BACKGROUND_SCALE}
+	this.hills.Transform.Position = [2]float32{0.0, res.Y() - float32(this.hills.Texture.GetHeight())*BACKGROUND_SCALE}
+	this.hills.NotRelativeToCamera = 0
+	this.hills.Depth = BACKGROUND_DEPTH
+	gohome.RenderMgr.AddObject(&this.hills)
+
+	for i := 0; i < len(this.clouds); i++ {
+		c := &this.clouds[i]
+		c.Init("BackgroundClouds")
+		c.Transform.Scale = [2]float32{BACKGROUND_SCALE, BACKGROUND_SCALE}
+		c.NotRelativeToCamera = 0
+		c.Depth = BACKGROUND_DEPTH
+		gohome.RenderMgr.AddObject(c)
+	}
+	this.scroll = 0.0
+	this.Update(0.0)
+
+	gohome.UpdateMgr.AddObject(this)
+}
+
+func (this *LevelSelectBackground) Update(delta_time float32) {
+	width := float32(this.clouds[0].Texture.GetWidth()) * BACKGROUND_SCALE
+	this.scroll += BACKGROUND_CLOUD_SPEED * delta_time
+	for this.scroll >= width {
+		this.scroll -= width
+	}
+	for i := 0; i < len(this.clouds); i++ {
+		this.clouds[i].Transform.Position = [2]float32{float32(i)*width - this.scroll, 0.0}
+	}
+}
+
+func (this *LevelSelectBackground) Terminate() {
+	gohome.UpdateMgr.RemoveObject(this)
+	gohome.RenderMgr.RemoveObject(&this.hills)
+	for i := 0; i < len(this.clouds); i++ {
+		gohome.RenderMgr.RemoveObject(&this.clouds[i])
+	}
+}
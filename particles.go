@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"strings"
+)
+
+const PARTICLES_PATH = "assets/particles/"
+
+type ParticleConfig struct {
+	Burst      int        `json:"burst"`
+	Rate       float32    `json:"rate"`
+	Duration   float32    `json:"duration"`
+	Lifetime   [2]float32 `json:"lifetime"`
+	Speed      [2]float32 `json:"speed"`
+	Angle      [2]float32 `json:"angle"`
+	Gravity    float32    `json:"gravity"`
+	Damping    float32    `json:"damping"`
+	Size       [2]float32 `json:"size"`
+	ColorStart [4]uint8   `json:"color_start"`
+	ColorEnd   [4]uint8   `json:"color_end"`
+}
+
+var ParticleConfigs = make(map[string]*ParticleConfig)
+
+func LoadParticleConfigs() {
+	files, err := ioutil.ReadDir(PARTICLES_PATH)
+	if err != nil {
+		gohome.ErrorMgr.Error("Particles", PARTICLES_PATH, err.Error())
+		return
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		data, err := ioutil.ReadFile(PARTICLES_PATH + f.Name())
+		if err != nil {
+			gohome.ErrorMgr.Error("Particles", f.Name(), err.Error())
+			continue
+		}
+		var config ParticleConfig
+		if err = json.Unmarshal(data, &config); err != nil {
+			gohome.ErrorMgr.Error("Particles", f.Name(), err.Error())
+			continue
+		}
+		ParticleConfigs[strings.TrimSuffix(f.Name(), ".json")] = &config
+	}
+}
+
+func randRange(r [2]float32) float32 {
+	return r[0] + rand.Float32()*(r[1]-r[0])
+}
+
+func lerpColor(a, b [4]uint8, t float32) gohome.Color {
+	var c [4]uint8
+	for i := 0; i < 4; i++ {
+		c[i] = uint8(float32(a[i]) + (float32(b[i])-float32(a[i]))*t)
+	}
+	return gohome.Color{c[0], c[1], c[2], c[3]}
+}
+
+type particle struct {
+	Position mgl32.Vec2
+	Velocity mgl32.Vec2
+	Size     float32
+	Lifetime float32
+	time     float32
+}
+
+type ParticleEmitter struct {
+	Config   *ParticleConfig
+	Position mgl32.Vec2
+	Extent   mgl32.Vec2
+	Emitting bool
+
+	particles   []particle
+	time        float32
+	accumulator float32
+}
+
+func (this *ParticleEmitter) Init(config *ParticleConfig, pos, extent mgl32.Vec2) {
+	this.Config = config
+	this.Position = pos
+	this.Extent = extent
+	this.Emitting = config.Rate > 0.0
+
+	for i := 0; i < config.Burst; i++ {
+		this.emit()
+	}
+}
+
+func (this *ParticleEmitter) emit() {
+	c := this.Config
+	angle := float64(mgl32.DegToRad(randRange(c.Angle)))
+	speed := randRange(c.Speed)
+	this.particles = append(this.particles, particle{
+		Position: this.Position.Add(this.Extent.Mul(rand.Float32())),
+		Velocity: [2]float32{float32(math.Cos(angle)) * speed, -float32(math.Sin(angle)) * speed},
+		Size:     randRange(c.Size),
+		Lifetime: randRange(c.Lifetime),
+	})
+}
+
+func (this *ParticleEmitter) Update(delta_time float32) {
+	c := this.Config
+	if this.Emitting {
+		this.time += delta_time
+		this.accumulator += delta_time * c.Rate
+		for ; this.accumulator >= 1.0; this.accumulator -= 1.0 {
+			this.emit()
+		}
+		if c.Duration > 0.0 && this.time >= c.Duration {
+			this.Emitting = false
+		}
+	}
+
+	alive := this.particles[:0]
+	for _, p := range this.particles {
+		p.time += delta_time
+		if p.time >= p.Lifetime {
+			continue
+		}
+		p.Velocity[1] += c.Gravity * delta_time
+		p.Velocity = p.Velocity.Mul(mgl32.Clamp(1.0-c.Damping*delta_time, 0.0, 1.0))
+		p.Position = p.Position.Add(p.Velocity.Mul(delta_time))
+		alive = append(alive, p)
+	}
+	this.particles = alive
+}
+
+func (this *ParticleEmitter) appendTriangles(tris []gohome.Triangle2D) []gohome.Triangle2D {
+	for _, p := range this.particles {
+		col := lerpColor(this.Config.ColorStart, this.Config.ColorEnd, p.time/p.Lifetime)
+		half := p.Size / 2.0
+		var rect gohome.Rectangle2D
+		rect[0].Make(p.Position.Add([2]float32{-half, -half}), col)
+		rect[1].Make(p.Position.Add([2]float32{half, -half}), col)
+		rect[2].Make(p.Position.Add([2]float32{half, half}), col)
+		rect[3].Make(p.Position.Add([2]float32{-half, half}), col)
+		t := rect.ToTriangles()
+		tris = append(tris, t[:]...)
+	}
+	return tris
+}
+
+func (this *ParticleEmitter) Done() bool {
+	return !this.Emitting && len(this.particles) == 0
+}
+
+// All emitters are drawn as one shape which is rebuilt once per frame, the
+// engine can't update a shape in place
+type ParticleSystem struct {
+	gohome.Shape2D
+
+	emitters  []*ParticleEmitter
+	triangles []gohome.Triangle2D
+	loaded    bool
+}
+
+func (this *ParticleSystem) Init() {
+	this.emitters = nil
+	this.loaded = false
+	LevelUpdates.AddObject(this)
+	gohome.RenderMgr.AddObject(this)
+	Entities.Add(this)
+}
+
+func (this *ParticleSystem) Spawn(name string, pos mgl32.Vec2) *ParticleEmitter {
+	return this.SpawnLine(name, pos, pos)
+}
+
+func (this *ParticleSystem) SpawnLine(name string, from, to mgl32.Vec2) *ParticleEmitter {
+	config, ok := ParticleConfigs[name]
+	if !ok {
+		gohome.ErrorMgr.Warning("Particles", name, "Couldn't find particle config")
+		return nil
+	}
+	emitter := &ParticleEmitter{}
+	emitter.Init(config, from, to.Sub(from))
+	this.emitters = append(this.emitters, emitter)
+	return emitter
+}
+
+func (this *ParticleSystem) Update(delta_time float32) {
+	alive := this.emitters[:0]
+	for _, e := range this.emitters {
+		e.Update(delta_time)
+		if !e.Done() {
+			alive = append(alive, e)
+		}
+	}
+	for i := len(alive); i < len(this.emitters); i++ {
+		this.emitters[i] = nil
+	}
+	this.emitters = alive
+
+	this.rebuild()
+}
+
+func (this *ParticleSystem) rebuild() {
+	if this.loaded {
+		this.Shape2D.Terminate()
+		this.loaded = false
+	}
+	this.triangles = this.triangles[:0]
+	for _, e := range this.emitters {
+		this.triangles = e.appendTriangles(this.triangles)
+	}
+	if len(this.triangles) == 0 {
+		this.Visible = false
+		return
+	}
+
+	this.Shape2D.Init()
+	this.loaded = true
+	this.Depth = SPECIAL_DEPTH
+	this.AddTriangles(this.triangles)
+	this.Load()
+	this.SetDrawMode(gohome.DRAW_MODE_TRIANGLES)
+}
+
+func (this *ParticleSystem) Terminate() {
+	this.emitters = nil
+	this.triangles = nil
+	gohome.RenderMgr.RemoveObject(this)
+	if this.loaded {
+		this.Shape2D.Terminate()
+		this.loaded = false
+	}
+	LevelUpdates.RemoveObject(this)
+	Entities.Remove(this)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+)
+
+const (
+	PHYSICS_TIMESTEP  float32 = 1.0 / 60.0
+	PHYSICS_MAX_STEPS float32 = 5.0
+)
+
+type FixedUpdater interface {
+	FixedUpdate(delta_time float32)
+}
+
+type FixedStepper struct {
+	Alpha float32
+
+	accumulator float32
+	controllers []FixedUpdater
+	connectors  []*Connector
+	updating    bool
+	removed     bool
+}
+
+func (this *FixedStepper) Init() {
+	this.Alpha = 1.0
+	this.accumulator = 0.0
+	this.controllers = this.controllers[:0]
+	this.connectors = this.connectors[:0]
+	this.updating = false
+	this.removed = false
+}
+
+func (this *FixedStepper) AddController(c FixedUpdater) {
+	this.controllers = append(this.controllers, c)
+}
+
+func (this *FixedStepper) RemoveController(c FixedUpdater) {
+	for i := 0; i < len(this.controllers); i++ {
+		if this.controllers[i] == c {
+			this.controllers[i] = nil
+			this.removed = true
+		}
+	}
+	if !this.updating {
+		this.compact()
+	}
+}
+
+func (this *FixedStepper) AddConnector(c *Connector) {
+	this.connectors = append(this.connectors, c)
+}
+
+func (this *FixedStepper) RemoveConnector(c *Connector) {
+	for i := 0; i < len(this.connectors); i++ {
+		if this.connectors[i] == c {
+			this.connectors[i] = nil
+			this.removed = true
+		}
+	}
+	if !this.updating {
+		this.compact()
+	}
+}
+
+func (this *FixedStepper) compact() {
+	if !this.removed {
+		return
+	}
+	controllers := this.controllers[:0]
+	for _, c := range this.controllers {
+		if c != nil {
+			controllers = append(controllers, c)
+		}
+	}
+	for i := len(controllers); i < len(this.controllers); i++ {
+		this.controllers[i] = nil
+	}
+	this.controllers = controllers
+
+	connectors := this.connectors[:0]
+	for _, c := range this.connectors {
+		if c != nil {
+			connectors = append(connectors, c)
+		}
+	}
+	for i := len(connectors); i < len(this.connectors); i++ {
+		this.connectors[i] = nil
+	}
+	this.connectors = connectors
+	this.removed = false
+}
+
+func (this *FixedStepper) Update(delta_time float32) {
+	this.accumulator += delta_time
+	if max := PHYSICS_TIMESTEP * PHYSICS_MAX_STEPS; this.accumulator > max {
+		this.accumulator = max
+	}
+	this.updating = true
+	for this.accumulator >= PHYSICS_TIMESTEP {
+		for i := 0; i < len(this.controllers); i++ {
+			if c := this.controllers[i]; c != nil {
+				c.FixedUpdate(PHYSICS_TIMESTEP)
+			}
+		}
+		for _, c := range this.connectors {
+			if c != nil {
+				c.prevPosition, c.prevRotation = c.read()
+			}
+		}
+		start := Profile.Start()
+		PhysicsMgr.Update(PHYSICS_TIMESTEP)
+		Profile.End(PROFILE_PHYSICS, start)
+		for _, c := range this.connectors {
+			if c != nil {
+				c.position, c.rotation = c.read()
+			}
+		}
+		this.accumulator -= PHYSICS_TIMESTEP
+	}
+	this.updating = false
+	this.compact()
+
+	this.Alpha = this.accumulator / PHYSICS_TIMESTEP
+	for _, c := range this.connectors {
+		c.Interpolate(this.Alpha)
+	}
+}
+
+type Connector struct {
+	Transform *gohome.TransformableObject2D
+	Body      *box2d.B2Body
+	Offset    mgl32.Vec2
+
+	prevPosition mgl32.Vec2
+	position     mgl32.Vec2
+	prevRotation float32
+	rotation     float32
+}
+
+func (this *Connector) Init(transform *gohome.TransformableObject2D, body *box2d.B2Body) {
+	this.Transform = transform
+	this.Body = body
+	this.Update()
+	Stepper.AddConnector(this)
+}
+
+func (this *Connector) read() (mgl32.Vec2, float32) {
+	return physics2d.ToPixelCoordinates(this.Body.GetPosition()), mgl32.RadToDeg(float32(this.Body.GetAngle()))
+}
+
+func (this *Connector) Update() {
+	this.position, this.rotation = this.read()
+	this.prevPosition, this.prevRotation = this.position, this.rotation
+	this.Interpolate(1.0)
+}
+
+func (this *Connector) Interpolate(alpha float32) {
+	pos := this.prevPosition.Add(this.position.Sub(this.prevPosition).Mul(alpha))
+	this.Transform.Position = pos.Add(this.Offset)
+	this.Transform.Rotation = this.prevRotation + (this.rotation-this.prevRotation)*alpha
+}
+
+func (this *Connector) Terminate() {
+	Stepper.RemoveConnector(this)
+}
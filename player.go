@@ -24,12 +24,29 @@ const (
 	PLAYER_FEET_SENSOR_OFFSET_X float32 = 0.0
 	PLAYER_FEET_SENSOR_OFFSET_Y float32 = 16.0 - PLAYER_FEET_SENSOR_HEIGHT/2.0
 
+	PLAYER_WALL_SENSOR_WIDTH    float32 = 2.0
+	PLAYER_WALL_SENSOR_HEIGHT   float32 = PLAYER_HEIGHT / 2.0
+	PLAYER_WALL_SENSOR_OFFSET_X float32 = PLAYER_WIDTH/2.0 + PLAYER_WALL_SENSOR_WIDTH/2.0
+	PLAYER_WALL_SENSOR_OFFSET_Y float32 = 0.0
+
+	PLAYER_WALL_SLIDE_VELOCITY float32 = 30.0
+	PLAYER_WALL_JUMP_FORCE_X   float32 = 12.0
+	PLAYER_WALL_JUMP_FORCE_Y   float32 = PLAYER_JUMP_FORCE
+	PLAYER_WALL_JUMP_LOCK_TIME float32 = 0.2
+
+	PLAYER_MAX_GROUND_SLOPE float32 = 50.0
+
 	PLAYER_ENEMY_BOUNCE float32 = -100.0
 
-	NO_ANIM    uint8 = 0
-	ANIM_WALK  uint8 = 1
-	ANIM_FALL  uint8 = 2
-	ANIM_SHOOT uint8 = 3
+	NO_ANIM         uint8 = 0
+	ANIM_WALK       uint8 = 1
+	ANIM_FALL       uint8 = 2
+	ANIM_SHOOT      uint8 = 3
+	ANIM_WALL_SLIDE uint8 = 4
+
+	WALL_NONE  int8 = 0
+	WALL_LEFT  int8 = -1
+	WALL_RIGHT int8 = 1
 
 	PLAYER_FRAME_WIDTH  float32 = 14.0
 	PLAYER_FRAME_HEIGHT float32 = 29.0
@@ -39,35 +56,59 @@ const (
 	PLAYER_PREVX_THRESHOLD float32 = 2.0
 	PLAYER_JUMP_THRESHOLD  float32 = 10.0
 
+	PLAYER_DROP_TIME float32 = ONE_WAY_DROP_TIME
+
+	PLAYER_LAND_DUST_VELOCITY float32 = 60.0
+
 	PLAYER_MIN_DISTANCE float32 = 10.0
 	PLAYER_MAX_DISTANCE float32 = 180.0
+
+	PLAYER_NOCLIP_SPEED float32 = 200.0
 )
 
 type Player struct {
 	gohome.Sprite2D
-	connector       physics2d.PhysicsConnector2D
-	body            *box2d.B2Body
-	targetCameraPos mgl32.Vec2
-	PhysicsMgr      *physics2d.PhysicsManager2D
-	Inventory       InventoryBar
-	scope           gohome.Sprite2D
+	connector  Connector
+	body       *box2d.B2Body
+	PhysicsMgr *physics2d.PhysicsManager2D
+	Inventory  InventoryBar
+	scope      gohome.Sprite2D
 
 	weapons       []Weapon
 	currentWeapon uint8
 	terminated    bool
 	dead          bool
+	God           bool
+	noclip        bool
 
 	currentAnimation *gohome.Tweenset
 	currentAnim      uint8
 
-	walkAnimation  gohome.Tweenset
-	fallAnimation  gohome.Tweenset
-	shootAnimation gohome.Tweenset
-
-	paused bool
-
-	jumpSound  gohome.Sound
-	shootSound gohome.Sound
+	walkAnimation      gohome.Tweenset
+	fallAnimation      gohome.Tweenset
+	shootAnimation     gohome.Tweenset
+	wallSlideAnimation gohome.Tweenset
+
+	Movement     PlayerMovement
+	wallSliding  bool
+	wallJumpLock float32
+	coyoteTime   float32
+	jumpBuffer   float32
+	jumpHeld     bool
+	groundVel    box2d.B2Vec2
+	wasGrounded  bool
+	fallVelocity float32
+
+	dropTime float32
+
+	contacts          ContactTracker
+	stompContacts     ContactSubscription
+	enemyContacts     ContactCounter
+	spikeContacts     ContactCounter
+	spikeFeetContacts ContactCounter
+	stompedEnemies    []*Enemy
+
+	jumpSound gohome.Sound
 }
 
 func (this *Player) Died() bool {
@@ -78,12 +119,16 @@ func (this *Player) Init(pos mgl32.Vec2, pmgr *physics2d.PhysicsManager2D) {
 	this.Sprite2D.Init("Player")
 	this.Transform.Position = pos
 	this.Transform.Origin = [2]float32{0.5, 0.5}
+	this.Movement = DefaultPlayerMovement()
 
 	this.createBody(pmgr)
-	this.connector.Init(this.Transform, this.body, &PhysicsMgr)
+	this.initContacts()
+	this.connector.Init(this.Transform, this.body)
 
-	gohome.UpdateMgr.AddObject(this)
+	LevelUpdates.AddObject(this)
 	gohome.RenderMgr.AddObject(this)
+	Stepper.AddController(this)
+	Entities.Add(this)
 
 	this.PhysicsMgr = pmgr
 
@@ -101,9 +146,30 @@ func (this *Player) Init(pos mgl32.Vec2, pmgr *physics2d.PhysicsManager2D) {
 	gohome.RenderMgr.AddObject(&this.scope)
 }
 
+func (this *Player) initContacts() {
+	this.stompedEnemies = nil
+
+	this.contacts.Init(ContactFilter{Body: this.body})
+	this.stompContacts = ContactSubscription{
+		ContactFilter: ContactFilter{Body: this.body, Other: ENEMY_CATEGORY},
+		Begin:         this.onEnemyContact,
+	}
+	Contacts.Subscribe(&this.stompContacts)
+	this.enemyContacts.Init(ContactFilter{Body: this.body, Category: PLAYER_CATEGORY, Other: ENEMY_CATEGORY})
+	this.spikeContacts.Init(ContactFilter{Body: this.body, Category: PLAYER_CATEGORY, Other: SPIKE_CATEGORY})
+	this.spikeFeetContacts.Init(ContactFilter{Body: this.body, Category: PLAYER_FEET_CATEGORY, Other: SPIKE_CATEGORY})
+}
+
+func (this *Player) terminateContacts() {
+	this.contacts.Terminate()
+	Contacts.Unsubscribe(&this.stompContacts)
+	this.enemyContacts.Terminate()
+	this.spikeContacts.Terminate()
+	this.spikeFeetContacts.Terminate()
+}
+
 func (this *Player) initSounds() {
 	this.jumpSound = gohome.ResourceMgr.GetSound("Jump")
-	this.shootSound = gohome.ResourceMgr.GetSound("Shoot")
 }
 
 func (this *Player) setupAnimations() {
@@ -145,17 +211,30 @@ func (this *Player) setupAnimations() {
 			[2]float32{PLAYER_FRAME_WIDTH * 2, PLAYER_FRAME_HEIGHT * 4},
 		},
 	}, PLAYER_FRAME_TIME)
+	this.wallSlideAnimation = gohome.SpriteAnimation2DRegions([]gohome.TextureRegion{
+		gohome.TextureRegion{
+			[2]float32{0, PLAYER_FRAME_HEIGHT * 4},
+			[2]float32{PLAYER_FRAME_WIDTH * 1, PLAYER_FRAME_HEIGHT * 5},
+		},
+		gohome.TextureRegion{
+			[2]float32{PLAYER_FRAME_WIDTH * 1, PLAYER_FRAME_HEIGHT * 4},
+			[2]float32{PLAYER_FRAME_WIDTH * 2, PLAYER_FRAME_HEIGHT * 5},
+		},
+	}, PLAYER_FRAME_TIME)
 
 	this.walkAnimation.Loop = true
 	this.fallAnimation.Loop = true
+	this.wallSlideAnimation.Loop = true
 
 	this.walkAnimation.SetParent(&this.Sprite2D)
 	this.fallAnimation.SetParent(&this.Sprite2D)
 	this.shootAnimation.SetParent(&this.Sprite2D)
+	this.wallSlideAnimation.SetParent(&this.Sprite2D)
 
-	gohome.UpdateMgr.AddObject(&this.walkAnimation)
-	gohome.UpdateMgr.AddObject(&this.fallAnimation)
-	gohome.UpdateMgr.AddObject(&this.shootAnimation)
+	LevelUpdates.AddObject(&this.walkAnimation)
+	LevelUpdates.AddObject(&this.fallAnimation)
+	LevelUpdates.AddObject(&this.shootAnimation)
+	LevelUpdates.AddObject(&this.wallSlideAnimation)
 
 	this.StopAnimation()
 }
@@ -174,6 +253,8 @@ func (this *Player) SetAnimation(anim uint8) {
 		this.currentAnimation = &this.fallAnimation
 	case ANIM_SHOOT:
 		this.currentAnimation = &this.shootAnimation
+	case ANIM_WALL_SLIDE:
+		this.currentAnimation = &this.wallSlideAnimation
 	}
 
 	this.currentAnimation.Start()
@@ -184,6 +265,7 @@ func (this *Player) StopAnimation() {
 	this.walkAnimation.Stop()
 	this.fallAnimation.Stop()
 	this.shootAnimation.Stop()
+	this.wallSlideAnimation.Stop()
 
 	this.TextureRegion = gohome.TextureRegion{
 		[2]float32{0, 0},
@@ -206,6 +288,8 @@ func (this *Player) updateAnimation() {
 			} else {
 				this.SetAnimation(ANIM_WALK)
 			}
+		} else if this.wallSliding {
+			this.SetAnimation(ANIM_WALL_SLIDE)
 		} else {
 			this.SetAnimation(ANIM_FALL)
 		}
@@ -228,7 +312,7 @@ func (this *Player) createBody(pmgr *physics2d.PhysicsManager2D) {
 	fdef.Density = 1.0 / (2.0 * math.Pi * radius * radius) * PLAYER_WEIGHT
 	fdef.Friction = PLAYER_FRICTION
 	fdef.Restitution = PLAYER_RESTITUITION
-	fdef.Filter.CategoryBits = PLAYER_FEET_CATEGORY
+	fdef.Filter = CollisionFilter(PLAYER_FEET_CATEGORY)
 
 	circleShape := box2d.MakeB2CircleShape()
 	circleShape.SetRadius(radius)
@@ -240,7 +324,7 @@ func (this *Player) createBody(pmgr *physics2d.PhysicsManager2D) {
 	this.body.CreateFixtureFromDef(&fdef)
 
 	fdef.Friction = 0.0
-	fdef.Filter.CategoryBits = PLAYER_CATEGORY
+	fdef.Filter = CollisionFilter(PLAYER_CATEGORY)
 	circleShape.M_p = physics2d.ToBox2DDirection([2]float32{0.0, -PLAYER_HEIGHT / 4.0})
 
 	this.body.CreateFixtureFromDef(&fdef)
@@ -261,15 +345,36 @@ func (this *Player) createBody(pmgr *physics2d.PhysicsManager2D) {
 		*v = box2d.B2Vec2Add(*v, offset)
 	}
 	fdef.IsSensor = true
-	fdef.Filter.CategoryBits = PLAYER_FEET_SENSOR_CATEGORY
+	fdef.Filter = CollisionFilter(PLAYER_FEET_SENSOR_CATEGORY)
 	this.body.CreateFixtureFromDef(&fdef)
 
+	for _, side := range [2]int8{WALL_LEFT, WALL_RIGHT} {
+		boxShape.SetAsBox(physics2d.ScalarToBox2D(PLAYER_WALL_SENSOR_WIDTH)/2.0, physics2d.ScalarToBox2D(PLAYER_WALL_SENSOR_HEIGHT)/2.0)
+		offset = physics2d.ToBox2DDirection([2]float32{float32(side) * PLAYER_WALL_SENSOR_OFFSET_X, PLAYER_WALL_SENSOR_OFFSET_Y})
+		for i := 0; i < 4; i++ {
+			v := &boxShape.M_vertices[i]
+			*v = box2d.B2Vec2Add(*v, offset)
+		}
+		if side == WALL_LEFT {
+			fdef.Filter = CollisionFilter(PLAYER_WALL_LEFT_SENSOR_CATEGORY)
+		} else {
+			fdef.Filter = CollisionFilter(PLAYER_WALL_RIGHT_SENSOR_CATEGORY)
+		}
+		this.body.CreateFixtureFromDef(&fdef)
+	}
+
 	this.body.SetLinearDamping(PLAYER_DAMPING)
+	this.body.SetUserData(this)
 }
 
 func (this *Player) updateVelocity(delta_time float32) {
+	if this.wallJumpLock > 0.0 {
+		this.wallJumpLock -= delta_time
+		return
+	}
+
 	vel := this.body.GetLinearVelocity()
-	pvel := physics2d.ToPixelDirection(vel).X()
+	pvel := physics2d.ToPixelDirection(box2d.B2Vec2Sub(vel, this.groundVel)).X()
 	if gohome.InputMgr.IsPressed(KEY_RIGHT) {
 		if pvel < PLAYER_MAX_VELOCITY {
 			force := physics2d.ToBox2DDirection([2]float32{PLAYER_VELOCITY * delta_time, 0.0})
@@ -285,11 +390,121 @@ func (this *Player) updateVelocity(delta_time float32) {
 	}
 }
 
-func (this *Player) handleJump() {
-	if (gohome.InputMgr.JustPressed(KEY_JUMP) || gohome.InputMgr.JustPressed(KEY_JUMP1)) && this.IsGrounded() {
-		this.body.ApplyLinearImpulseToCenter(physics2d.ToBox2DDirection([2]float32{0.0, -PLAYER_JUMP_FORCE}), true)
-		this.jumpSound.Play(false)
+func (this *Player) handleJump(delta_time float32) {
+	m := &this.Movement
+	// The feet sensor still overlaps the ground right after takeoff, so
+	// coyote time is only refreshed once the player stops rising
+	rising := physics2d.ToPixelDirection(box2d.B2Vec2Sub(this.body.GetLinearVelocity(), this.groundVel)).Y() < -PLAYER_JUMP_THRESHOLD
+	if this.IsGrounded() && !rising {
+		this.coyoteTime = m.CoyoteTime
+	} else if this.coyoteTime > 0.0 {
+		this.coyoteTime -= delta_time
+	}
+	if gohome.InputMgr.JustPressed(KEY_JUMP) || gohome.InputMgr.JustPressed(KEY_JUMP1) {
+		this.jumpBuffer = m.JumpBuffer
+	} else if this.jumpBuffer > 0.0 {
+		this.jumpBuffer -= delta_time
+	}
+
+	vel := this.body.GetLinearVelocity()
+	pvel := physics2d.ToPixelDirection(vel)
+	if this.jumpHeld {
+		if pvel.Y() >= 0.0 {
+			this.jumpHeld = false
+		} else if !gohome.InputMgr.IsPressed(KEY_JUMP) && !gohome.InputMgr.IsPressed(KEY_JUMP1) {
+			vel.Y *= float64(m.JumpCutFactor)
+			this.body.SetLinearVelocity(vel)
+			this.jumpHeld = false
+		}
+	}
+
+	if this.jumpBuffer <= 0.0 {
+		return
+	}
+	if this.coyoteTime > 0.0 {
+		if pvel.Y() > 0.0 {
+			vel.Y = 0.0
+			this.body.SetLinearVelocity(vel)
+		}
+		this.body.ApplyLinearImpulseToCenter(physics2d.ToBox2DDirection([2]float32{0.0, -m.JumpForce}), true)
+		Mixer.PlaySound(this.jumpSound, CHANNEL_SFX)
+		this.jumpBuffer, this.coyoteTime = 0.0, 0.0
+		this.jumpHeld = true
+	} else if wall := this.TouchingWall(); m.WallJump && wall != WALL_NONE {
+		vel.X, vel.Y = 0.0, 0.0
+		this.body.SetLinearVelocity(vel)
+		this.body.ApplyLinearImpulseToCenter(physics2d.ToBox2DDirection([2]float32{-float32(wall) * m.WallJumpForce[0], -m.WallJumpForce[1]}), true)
+		this.wallJumpLock = m.WallJumpLockTime
+		this.wallSliding = false
+		Mixer.PlaySound(this.jumpSound, CHANNEL_SFX)
+		this.jumpBuffer = 0.0
+		this.jumpHeld = true
+	}
+}
+
+func (this *Player) handleWallSlide() {
+	this.wallSliding = false
+	if !this.Movement.WallSlide || this.IsGrounded() {
+		return
+	}
+	wall := this.TouchingWall()
+	if wall == WALL_NONE {
+		return
+	}
+	if !(wall == WALL_LEFT && gohome.InputMgr.IsPressed(KEY_LEFT)) && !(wall == WALL_RIGHT && gohome.InputMgr.IsPressed(KEY_RIGHT)) {
+		return
+	}
+
+	vel := this.body.GetLinearVelocity()
+	pvel := physics2d.ToPixelDirection(vel)
+	if pvel.Y() < 0.0 {
+		return
+	}
+	this.wallSliding = true
+	if pvel.Y() > this.Movement.WallSlideVelocity {
+		vel.Y = physics2d.ToBox2DDirection([2]float32{0.0, this.Movement.WallSlideVelocity}).Y
+		this.body.SetLinearVelocity(vel)
+	}
+}
+
+func (this *Player) TouchingWall() int8 {
+	for _, e := range this.contacts.Events {
+		if e.Other.IsSensor() || isOneWay(e.Other) {
+			continue
+		}
+		switch e.Fixture.GetFilterData().CategoryBits {
+		case PLAYER_WALL_LEFT_SENSOR_CATEGORY:
+			return WALL_LEFT
+		case PLAYER_WALL_RIGHT_SENSOR_CATEGORY:
+			return WALL_RIGHT
+		}
+	}
+	return WALL_NONE
+}
+
+func (this *Player) handleDrop(delta_time float32) {
+	if this.dropTime > 0.0 {
+		this.dropTime -= delta_time
+	}
+	if gohome.InputMgr.JustPressed(KEY_DOWN) && this.IsOnOneWay() {
+		this.dropTime = PLAYER_DROP_TIME
+	}
+}
+
+func (this *Player) Dropping() bool {
+	return this.dropTime > 0.0
+}
+
+func (this *Player) IsOnOneWay() bool {
+	for _, e := range this.contacts.Events {
+		if !e.Contact.IsEnabled() {
+			continue
+		}
+		if e.Fixture.GetFilterData().CategoryBits == PLAYER_FEET_CATEGORY && isOneWay(e.Other) {
+			return true
+		}
 	}
+	return false
 }
 
 const UP = true
@@ -326,7 +541,7 @@ func (this *Player) handleWeapon() {
 	w := this.weapons[this.currentWeapon]
 	if gohome.InputMgr.JustPressed(KEY_SHOOT) && w.GetAmmo() > 0 {
 		w.Use(mpos, this.calculateEnergy(mpos))
-		this.shootSound.Play(false)
+		Mixer.PlayAt("Shoot", CHANNEL_SFX, this.Transform.Position)
 		if this.currentAnim == NO_ANIM {
 			this.SetAnimation(ANIM_SHOOT)
 		}
@@ -374,6 +589,13 @@ func (this *Player) addWeapon(w Weapon) {
 	this.Inventory.AddWeapon(w)
 }
 
+func (this *Player) WeaponStates() (states []WeaponState) {
+	for _, w := range this.weapons {
+		states = append(states, WeaponState{weaponName(w), w.GetAmmo()})
+	}
+	return
+}
+
 func (this *Player) changeWeapon(dir bool) {
 	w := this.weapons[this.currentWeapon]
 	w.OnChange(OUT)
@@ -397,51 +619,99 @@ func (this *Player) changeWeapon(dir bool) {
 }
 
 func (this *Player) Die() {
+	if this.God || this.dead {
+		return
+	}
 	this.dead = true
+	CameraFX.AddTrauma(CAMERA_DEATH_TRAUMA)
 	this.terminateSprite()
 	for _, w := range this.weapons {
 		w.OnDie()
 	}
 }
 
-func (this *Player) Pause() {
-	for _, w := range this.weapons {
-		w.Pause()
+func (this *Player) Update(delta_time float32) {
+	defer Profile.End(PROFILE_PLAYER, Profile.Start())
+	this.updateScope()
+
+	this.checkEnemy()
+	this.checkSpikes()
+	if this.Died() {
+		return
 	}
 
-	this.paused = true
+	if !this.noclip {
+		this.handleDrop(delta_time)
+		this.handleJump(delta_time)
+	}
+	this.handleWeapon()
+	this.updateAnimation()
 }
 
-func (this *Player) Resume() {
-	for _, w := range this.weapons {
-		w.Resume()
+func (this *Player) FixedUpdate(delta_time float32) {
+	defer Profile.End(PROFILE_PLAYER, Profile.Start())
+	if this.Died() {
+		return
+	}
+	if this.noclip {
+		this.groundVel = box2d.B2Vec2{}
+		this.updateNoclip()
+		return
 	}
 
-	this.paused = false
+	this.updateGroundReference()
+	this.updateVelocity(delta_time)
+	this.handleWallSlide()
+	this.checkLanding()
 }
 
-func (this *Player) Update(delta_time float32) {
-	this.updateScope()
+func (this *Player) Noclip() bool {
+	return this.noclip
+}
 
-	if this.paused {
-		return
+func (this *Player) SetNoclip(noclip bool) {
+	this.noclip = noclip
+	for f := this.body.GetFixtureList(); f != nil; f = f.GetNext() {
+		filter := f.GetFilterData()
+		if noclip {
+			filter.MaskBits = 0
+		} else {
+			filter.MaskBits = CollisionMask(filter.CategoryBits)
+		}
+		f.SetFilterData(filter)
 	}
-
-	this.checkEnemy()
-	this.checkSpikes()
-	if this.Died() {
-		return
+	if noclip {
+		this.body.SetGravityScale(0.0)
+	} else {
+		this.body.SetGravityScale(1.0)
 	}
+	this.body.SetLinearVelocity(box2d.MakeB2Vec2(0.0, 0.0))
+}
 
-	this.updateVelocity(delta_time)
-	this.handleJump()
-	this.handleWeapon()
-	this.updateCamera(delta_time)
-	this.updateAnimation()
+func (this *Player) updateNoclip() {
+	var dir mgl32.Vec2
+	if gohome.InputMgr.IsPressed(KEY_RIGHT) {
+		dir[0] += 1.0
+	}
+	if gohome.InputMgr.IsPressed(KEY_LEFT) {
+		dir[0] -= 1.0
+	}
+	if gohome.InputMgr.IsPressed(KEY_JUMP) || gohome.InputMgr.IsPressed(KEY_JUMP1) {
+		dir[1] -= 1.0
+	}
+	if gohome.InputMgr.IsPressed(KEY_DOWN) {
+		dir[1] += 1.0
+	}
+	this.body.SetLinearVelocity(physics2d.ToBox2DDirection(dir.Mul(PLAYER_NOCLIP_SPEED)))
+}
 
-	if gohome.InputMgr.JustPressed(gohome.KeyO) {
-		this.Die()
+func (this *Player) checkLanding() {
+	grounded := this.IsGrounded()
+	if grounded && !this.wasGrounded && this.fallVelocity >= PLAYER_LAND_DUST_VELOCITY {
+		Particles.Spawn("dust", this.Transform.Position.Add([2]float32{0.0, PLAYER_HEIGHT / 2.0}))
 	}
+	this.wasGrounded = grounded
+	this.fallVelocity = physics2d.ToPixelDirection(this.body.GetLinearVelocity()).Y()
 }
 
 func (this *Player) updateScope() {
@@ -455,112 +725,119 @@ func (this *Player) updateScope() {
 	this.scope.Transform.Position = this.Transform.Position.Add(rel.Mul(energy))
 }
 
-func (this *Player) updateCamera(delta_time float32) {
-	boxXNC := float64(this.Transform.Position[0] / CAMERA_BOX_WIDTH)
-	boxYNC := float64(this.Transform.Position[1] / CAMERA_BOX_HEIGHT)
-	boxX := float32(math.Floor(boxXNC))
-	boxY := float32(math.Floor(boxYNC))
-	this.targetCameraPos[0] = boxX*CAMERA_BOX_WIDTH + CAMERA_OFFSET[0]
-	this.targetCameraPos[1] = boxY*CAMERA_BOX_HEIGHT + CAMERA_OFFSET[1]
-	var zero float32 = 0.0
-	mgl32.SetMax(&this.targetCameraPos[0], &zero)
-	mgl32.SetMax(&this.targetCameraPos[1], &zero)
+func (this *Player) AimDirection() mgl32.Vec2 {
+	mpos := gohome.InputMgr.Mouse.ToWorldPosition2D()
+	rel := mpos.Sub(this.Transform.Position)
+	if rel.Len() == 0.0 {
+		return rel
+	}
+	return rel.Normalize().Mul(this.calculateEnergy(mpos))
+}
 
-	Camera.Position = Camera.Position.Add(this.targetCameraPos.Sub(Camera.Position).Mul((1.0 / CAMERA_SPEED) * delta_time))
+func (this *Player) IsGrounded() bool {
+	ground, _ := this.findGround()
+	return ground != nil
 }
 
-func (this *Player) IsGrounded() (grounded bool) {
-	for ce := this.body.GetContactList(); ce != nil; ce = ce.Next {
-		c := ce.Contact
-		if !c.IsTouching() {
+func (this *Player) findGround() (ground *box2d.B2Fixture, point box2d.B2Vec2) {
+	point = this.body.GetPosition()
+	minNormalY := float32(math.Cos(float64(mgl32.DegToRad(PLAYER_MAX_GROUND_SLOPE))))
+	for _, e := range this.contacts.Events {
+		c := e.Contact
+		if !c.IsEnabled() {
 			continue
 		}
-		fa := c.GetFixtureA()
-		fb := c.GetFixtureB()
-		if fb.GetFilterData().CategoryBits&PLAYER_CATEGORY != 0 {
-			fa, fb = fb, fa
+		fa, fb := e.Fixture, e.Other
+		if fb.IsSensor() || (isOneWay(fb) && !landsOnOneWay(fb, fa)) {
+			continue
 		}
 
-		if fa.GetFilterData().CategoryBits == PLAYER_FEET_CATEGORY || fa.GetFilterData().CategoryBits == PLAYER_FEET_SENSOR_CATEGORY {
-			grounded = true
-			return
-		} else if fa.GetFilterData().CategoryBits == PLAYER_CATEGORY && fb.GetFilterData().CategoryBits&BALL_CATEGORY != 0 {
-			ballP := fb.GetBody().GetPosition()
-			var mani box2d.B2WorldManifold
-			c.GetWorldManifold(&mani)
-			for _, p := range mani.Points {
-				rel := box2d.B2Vec2Sub(p, ballP)
-				angle := math.Atan2(rel.Y, rel.X) / math.Pi * 180.0
-				if angle >= 90-30 && angle <= 90+30 {
-					grounded = true
-					return
-				}
+		if fa.GetFilterData().CategoryBits == PLAYER_FEET_SENSOR_CATEGORY {
+			if ground == nil {
+				ground = fb
 			}
+			continue
+		}
+		if fa.IsSensor() || c.GetManifold().PointCount == 0 {
+			continue
+		}
+
+		var mani box2d.B2WorldManifold
+		c.GetWorldManifold(&mani)
+		normal := physics2d.ToPixelDirection(mani.Normal).Normalize()
+		if c.GetFixtureA() == fa {
+			normal = normal.Mul(-1.0)
+		}
+		if -normal.Y() >= minNormalY {
+			return fb, mani.Points[0]
 		}
 	}
-	grounded = false
 	return
 }
 
-func (this *Player) checkEnemy() {
-	var fc, bc bool = false, false
-	var enemy *Enemy
-	for ce := this.body.GetContactList(); ce != nil; ce = ce.Next {
-		c := ce.Contact
-		if !c.IsTouching() {
-			continue
-		}
-		fa := c.GetFixtureA()
-		fb := c.GetFixtureB()
+// The player velocity is kept relative to the ground and rebuilt on top of
+// the current ground velocity every step. In the air the last ground velocity
+// stays the reference, so jumping off a platform keeps its momentum
+func (this *Player) updateGroundReference() {
+	rel := box2d.B2Vec2Sub(this.body.GetLinearVelocity(), this.groundVel)
+	ground, point := this.findGround()
+	if ground == nil {
+		return
+	}
 
-		if fb.GetFilterData().CategoryBits&PLAYER_CATEGORY != 0 {
-			fa, fb = fb, fa
-		}
-		if fb.GetFilterData().CategoryBits != ENEMY_CATEGORY {
-			continue
+	body := ground.GetBody()
+	var vel box2d.B2Vec2
+	if body.GetType() == box2d.B2BodyType.B2_dynamicBody {
+		vel = body.GetLinearVelocity()
+	} else {
+		vel = body.GetLinearVelocityFromWorldPoint(point)
+	}
+	this.groundVel = vel
+	this.body.SetLinearVelocity(box2d.B2Vec2Add(vel, rel))
+}
+
+func (this *Player) onEnemyContact(event *ContactEvent) {
+	enemy, ok := event.Other.GetBody().GetUserData().(*Enemy)
+	if !ok {
+		return
+	}
+	switch event.Fixture.GetFilterData().CategoryBits {
+	case PLAYER_FEET_SENSOR_CATEGORY, PLAYER_FEET_CATEGORY:
+		for _, e := range this.stompedEnemies {
+			if e == enemy {
+				return
+			}
 		}
+		this.stompedEnemies = append(this.stompedEnemies, enemy)
+	}
+}
 
-		switch fa.GetFilterData().CategoryBits {
-		case PLAYER_CATEGORY:
-			bc = true
-		case PLAYER_FEET_SENSOR_CATEGORY, PLAYER_FEET_CATEGORY:
-			enemy = fb.GetBody().GetUserData().(*Enemy)
-			fc = true
-		default:
-			break
+func (this *Player) checkEnemy() {
+	enemies := this.stompedEnemies
+	this.stompedEnemies = nil
+
+	var stomped bool
+	for _, enemy := range enemies {
+		if enemy.terminated {
+			continue
 		}
+		enemy.Die()
+		enemy.Terminate()
+		stomped = true
 	}
 
-	if fc {
+	if stomped {
 		vel := this.body.GetLinearVelocity()
 		vel.Y = -physics2d.ScalarToBox2D(PLAYER_ENEMY_BOUNCE)
 		this.body.SetLinearVelocity(vel)
-		enemy.Die()
-		enemy.Terminate()
-	} else if bc {
+	} else if this.enemyContacts.Touching() {
 		this.Die()
 	}
 }
 
 func (this *Player) checkSpikes() {
-	for ce := this.body.GetContactList(); ce != nil; ce = ce.Next {
-		c := ce.Contact
-		if !c.IsTouching() {
-			continue
-		}
-		fa, fb := c.GetFixtureA(), c.GetFixtureB()
-		if fb.GetFilterData().CategoryBits&PLAYER_CATEGORY != 0 {
-			fa, fb = fb, fa
-		}
-
-		if fa.GetFilterData().CategoryBits == PLAYER_FEET_SENSOR_CATEGORY {
-			continue
-		}
-
-		if fb.GetFilterData().CategoryBits == SPIKE_CATEGORY {
-			this.Die()
-			break
-		}
+	if this.spikeContacts.Touching() || this.spikeFeetContacts.Touching() {
+		this.Die()
 	}
 }
 
@@ -605,14 +882,17 @@ func (this *Player) terminateSprite() {
 		return
 	}
 
-	gohome.UpdateMgr.RemoveObject(this)
-	gohome.UpdateMgr.RemoveObject(&this.walkAnimation)
-	gohome.UpdateMgr.RemoveObject(&this.fallAnimation)
-	gohome.UpdateMgr.RemoveObject(&this.shootAnimation)
+	LevelUpdates.RemoveObject(this)
+	Stepper.RemoveController(this)
+	LevelUpdates.RemoveObject(&this.walkAnimation)
+	LevelUpdates.RemoveObject(&this.fallAnimation)
+	LevelUpdates.RemoveObject(&this.shootAnimation)
+	LevelUpdates.RemoveObject(&this.wallSlideAnimation)
 	gohome.RenderMgr.RemoveObject(this)
 	gohome.RenderMgr.RemoveObject(&this.scope)
 
 	this.Inventory.Terminate()
+	this.terminateContacts()
 	if this.body != nil {
 		this.PhysicsMgr.World.DestroyBody(this.body)
 	}
@@ -621,6 +901,7 @@ func (this *Player) terminateSprite() {
 }
 
 func (this *Player) Terminate() {
+	Entities.Remove(this)
 	this.terminateSprite()
 	for _, w := range this.weapons {
 		w.Terminate()
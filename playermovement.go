@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/PucklaMotzer09/tmx"
+)
+
+const (
+	PLAYER_JUMP_CUT_FACTOR float32 = 0.5
+	PLAYER_COYOTE_TIME     float32 = 0.1
+	PLAYER_JUMP_BUFFER     float32 = 0.1
+)
+
+type PlayerMovement struct {
+	JumpForce     float32
+	JumpCutFactor float32
+	CoyoteTime    float32
+	JumpBuffer    float32
+
+	WallSlide         bool
+	WallSlideVelocity float32
+	WallJump          bool
+	WallJumpForce     [2]float32
+	WallJumpLockTime  float32
+}
+
+func DefaultPlayerMovement() PlayerMovement {
+	return PlayerMovement{
+		JumpForce:     PLAYER_JUMP_FORCE,
+		JumpCutFactor: PLAYER_JUMP_CUT_FACTOR,
+		CoyoteTime:    PLAYER_COYOTE_TIME,
+		JumpBuffer:    PLAYER_JUMP_BUFFER,
+
+		WallSlide:         false,
+		WallSlideVelocity: PLAYER_WALL_SLIDE_VELOCITY,
+		WallJump:          false,
+		WallJumpForce:     [2]float32{PLAYER_WALL_JUMP_FORCE_X, PLAYER_WALL_JUMP_FORCE_Y},
+		WallJumpLockTime:  PLAYER_WALL_JUMP_LOCK_TIME,
+	}
+}
+
+func (this *PlayerMovement) Load(props *tmx.Properties) {
+	this.JumpForce = getPropertyFloat(props, "jump_force", this.JumpForce)
+	this.JumpCutFactor = getPropertyFloat(props, "jump_cut", this.JumpCutFactor)
+	this.CoyoteTime = getPropertyFloat(props, "coyote_time", this.CoyoteTime)
+	this.JumpBuffer = getPropertyFloat(props, "jump_buffer", this.JumpBuffer)
+
+	this.WallSlide = getPropertyBool(props, "wall_slide", this.WallSlide)
+	this.WallSlideVelocity = getPropertyFloat(props, "wall_slide_velocity", this.WallSlideVelocity)
+	this.WallJump = getPropertyBool(props, "wall_jump", this.WallJump)
+	this.WallJumpForce[0] = getPropertyFloat(props, "wall_jump_force_x", this.WallJumpForce[0])
+	this.WallJumpForce[1] = getPropertyFloat(props, "wall_jump_force_y", this.WallJumpForce[1])
+	this.WallJumpLockTime = getPropertyFloat(props, "wall_jump_lock", this.WallJumpLockTime)
+}
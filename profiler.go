@@ -0,0 +1,34 @@
+//go:build debug
+// +build debug
+
+package main
+
+import (
+	"time"
+)
+
+type Profiler struct {
+	times [NUM_PROFILE_SECTIONS]time.Duration
+}
+
+func (this *Profiler) Init() {
+	this.Flush()
+}
+
+func (this *Profiler) Start() time.Time {
+	return time.Now()
+}
+
+// Use as defer Profile.End(section, Profile.Start()) at the top of a function
+func (this *Profiler) End(section ProfileSection, start time.Time) {
+	this.times[section] += time.Since(start)
+}
+
+// Returns the milliseconds spent in every section since the last flush
+func (this *Profiler) Flush() (ms [NUM_PROFILE_SECTIONS]float32) {
+	for i, t := range this.times {
+		ms[i] = float32(t.Seconds() * 1000.0)
+		this.times[i] = 0
+	}
+	return
+}
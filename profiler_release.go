@@ -0,0 +1,25 @@
+//go:build !debug
+// +build !debug
+
+package main
+
+import (
+	"time"
+)
+
+type Profiler struct {
+}
+
+func (this *Profiler) Init() {
+}
+
+func (this *Profiler) Start() (start time.Time) {
+	return
+}
+
+func (this *Profiler) End(section ProfileSection, start time.Time) {
+}
+
+func (this *Profiler) Flush() (ms [NUM_PROFILE_SECTIONS]float32) {
+	return
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/PucklaMotzer09/tmx"
+	"strconv"
+)
+
+func getProperty(props *tmx.Properties, name string) (string, bool) {
+	if props == nil {
+		return "", false
+	}
+	for i := 0; i < len(props.Properties); i++ {
+		p := props.Properties[i]
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+func getPropertyString(props *tmx.Properties, name string, def string) string {
+	if v, ok := getProperty(props, name); ok {
+		return v
+	}
+	return def
+}
+
+func getPropertyFloat(props *tmx.Properties, name string, def float32) float32 {
+	if v, ok := getProperty(props, name); ok {
+		if f, err := strconv.ParseFloat(v, 32); err == nil {
+			return float32(f)
+		}
+	}
+	return def
+}
+
+func getPropertyBool(props *tmx.Properties, name string, def bool) bool {
+	if v, ok := getProperty(props, name); ok {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return def
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"math"
+	"time"
+)
+
+const (
+	IMPACT_MIN_IMPULSE float64 = 0.2
+	IMPACT_MAX_IMPULSE float64 = 2.0
+	IMPACT_MIN_VOLUME  float32 = 0.2
+
+	IMPACT_MIN_APPROACH_VELOCITY float64 = 0.5
+
+	IMPACT_SOUND_INTERVAL float32 = 0.08
+	FREEZE_SOUND_INTERVAL float32 = 0.1
+
+	ROLLING_MIN_VOLUME float32 = 0.05
+	HUM_VOLUME         float32 = 0.6
+)
+
+var lastPlayed = make(map[string]time.Time)
+
+func canPlay(name string, interval float32) bool {
+	now := time.Now()
+	if last, ok := lastPlayed[name]; ok && now.Sub(last).Seconds() < float64(interval) {
+		return false
+	}
+	lastPlayed[name] = now
+	return true
+}
+
+func (this *AudioMixer) PlayLimitedAt(name string, channel AudioChannel, pos mgl32.Vec2, volume, interval float32) {
+	sound := Voice(name, pos)
+	if sound == nil {
+		return
+	}
+	volume *= this.Volume(channel) * attenuation(pos)
+	if volume == 0.0 || !canPlay(name, interval) {
+		return
+	}
+	sound.SetVolume(volume)
+	sound.Play(false)
+}
+
+// Speed with which the two bodies of contact move towards each other along
+// the contact normal
+func approachVelocity(contact box2d.B2ContactInterface) float64 {
+	if contact.GetManifold().PointCount == 0 {
+		return 0.0
+	}
+	var mani box2d.B2WorldManifold
+	contact.GetWorldManifold(&mani)
+	p := mani.Points[0]
+	va := contact.GetFixtureA().GetBody().GetLinearVelocityFromWorldPoint(p)
+	vb := contact.GetFixtureB().GetBody().GetLinearVelocityFromWorldPoint(p)
+	return -box2d.B2Vec2Dot(box2d.B2Vec2Sub(vb, va), mani.Normal)
+}
+
+func playImpactSound(contact box2d.B2ContactInterface, impulse float64) {
+	if impulse < IMPACT_MIN_IMPULSE {
+		return
+	}
+	for _, f := range [2]*box2d.B2Fixture{contact.GetFixtureA(), contact.GetFixtureB()} {
+		block := GetBlock(f.GetBody())
+		if block == nil || block.GetKind() != BLOCK_DEFAULT {
+			continue
+		}
+		t := float32(math.Min((impulse-IMPACT_MIN_IMPULSE)/(IMPACT_MAX_IMPULSE-IMPACT_MIN_IMPULSE), 1.0))
+		volume := IMPACT_MIN_VOLUME + (1.0-IMPACT_MIN_VOLUME)*t
+		pos := physics2d.ToPixelCoordinates(f.GetBody().GetPosition())
+		Mixer.PlayLimitedAt("Impact", CHANNEL_SFX, pos, volume, IMPACT_SOUND_INTERVAL)
+		return
+	}
+}
+
+// Every LoopSound plays on its own voice so two weapons don't fight over
+// the volume of one sound. It stays centered because its volume is mixed
+// from several bodies
+type LoopSound struct {
+	Name    string
+	Channel AudioChannel
+
+	sound gohome.Sound
+}
+
+func (this *LoopSound) SetVolume(volume float32) {
+	volume *= Mixer.Volume(this.Channel)
+	if volume <= 0.0 {
+		this.Stop()
+		return
+	}
+	if this.sound == nil {
+		if this.sound = ClaimVoice(this.Name); this.sound == nil {
+			return
+		}
+		this.sound.SetVolume(volume)
+		this.sound.Play(true)
+		return
+	}
+	this.sound.SetVolume(volume)
+}
+
+func (this *LoopSound) Stop() {
+	if this.sound == nil {
+		return
+	}
+	this.sound.Stop()
+	ReleaseVoice(this.sound)
+	this.sound = nil
+}
+
+func touchingAnything(body *box2d.B2Body) bool {
+	for ce := body.GetContactList(); ce != nil; ce = ce.Next {
+		if ce.Contact.IsTouching() {
+			return true
+		}
+	}
+	return false
+}
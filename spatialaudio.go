@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/binary"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"strconv"
+)
+
+const (
+	SPATIAL_AUDIO_MIN_DISTANCE float32 = CAMERA_BOX_WIDTH / 4.0
+	SPATIAL_AUDIO_MAX_DISTANCE float32 = AI_DISTANCE * 1.5
+	SPATIAL_AUDIO_MAX_PAN      float32 = 0.75
+
+	SOUND_VOICES    = 4
+	SOUND_PAN_STEPS = 5
+)
+
+// A gohome.Sound is a single source with a single volume and without a pan
+// control, so sounds which can overlap get a few copies for every pan step.
+// The copies are stereo with the mono samples split between left and right
+var soundVoices = make(map[string][][]gohome.Sound)
+var claimedVoices = make(map[gohome.Sound]bool)
+
+func LoadSoundVoices(name, fileName string) {
+	samples, sampleRate, err := readMonoWAV(fileName)
+	if err != nil {
+		gohome.ErrorMgr.Warning("Sound", name, "Couldn't pan it, it will be played centered: "+err.Error())
+		loadCenteredVoices(name, fileName)
+		return
+	}
+	steps := make([][]gohome.Sound, SOUND_PAN_STEPS)
+	for step := range steps {
+		data := panSamples(samples, panOfStep(step))
+		for i := 0; i < SOUND_VOICES; i++ {
+			voiceName := name + "#" + strconv.Itoa(step) + "#" + strconv.Itoa(i)
+			if sound := gohome.AudioMgr.CreateSound(voiceName, data, gohome.AUDIO_FORMAT_STEREO16, sampleRate); sound != nil {
+				steps[step] = append(steps[step], sound)
+			}
+		}
+	}
+	soundVoices[name] = steps
+}
+
+func loadCenteredVoices(name, fileName string) {
+	gohome.ResourceMgr.LoadSound(name, fileName)
+	sound := gohome.ResourceMgr.GetSound(name)
+	if sound == nil {
+		return
+	}
+	voices := []gohome.Sound{sound}
+	for i := 1; i < SOUND_VOICES; i++ {
+		if sound := gohome.AudioMgr.LoadSound(name+"#"+strconv.Itoa(i), fileName); sound != nil {
+			voices = append(voices, sound)
+		}
+	}
+	soundVoices[name] = [][]gohome.Sound{voices}
+}
+
+// Converts mono samples to 16 bit stereo where pan goes from -1 (left) to
+// 1 (right). The near side stays at full volume so centered sounds keep
+// their loudness
+func panSamples(samples []int16, pan float32) []byte {
+	left := mgl32.Min(1.0, 1.0-pan)
+	right := mgl32.Min(1.0, 1.0+pan)
+	data := make([]byte, len(samples)*4)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(data[i*4:], uint16(int16(float32(s)*left)))
+		binary.LittleEndian.PutUint16(data[i*4+2:], uint16(int16(float32(s)*right)))
+	}
+	return data
+}
+
+func panOfStep(step int) float32 {
+	return (float32(step)/float32(SOUND_PAN_STEPS-1)*2.0 - 1.0) * SPATIAL_AUDIO_MAX_PAN
+}
+
+func panStep(pos mgl32.Vec2) int {
+	halfWidth := float32(GAME_WIDTH) / Camera.Zoom / 2.0
+	pan := mgl32.Clamp((pos.X()-listenerPosition().X())/halfWidth, -1.0, 1.0)
+	return int((pan+1.0)/2.0*float32(SOUND_PAN_STEPS-1) + 0.5)
+}
+
+// Returns a voice of name panned towards pos which isn't playing or nil if
+// all of them are busy
+func Voice(name string, pos mgl32.Vec2) gohome.Sound {
+	steps, ok := soundVoices[name]
+	if !ok {
+		if sound := gohome.ResourceMgr.GetSound(name); sound != nil && !sound.IsPlaying() {
+			return sound
+		}
+		return nil
+	}
+	return freeVoice(steps, panStep(pos))
+}
+
+func freeVoice(steps [][]gohome.Sound, step int) gohome.Sound {
+	if len(steps) == 1 {
+		step = 0
+	}
+	for _, sound := range steps[step] {
+		if !claimedVoices[sound] && !sound.IsPlaying() {
+			return sound
+		}
+	}
+	return nil
+}
+
+// Reserves a centered voice for an owner which controls it over a longer
+// time like a looping sound, returns nil if all of them are busy
+func ClaimVoice(name string) gohome.Sound {
+	steps, ok := soundVoices[name]
+	if !ok {
+		return gohome.ResourceMgr.GetSound(name)
+	}
+	sound := freeVoice(steps, SOUND_PAN_STEPS/2)
+	if sound != nil {
+		claimedVoices[sound] = true
+	}
+	return sound
+}
+
+func ReleaseVoice(sound gohome.Sound) {
+	delete(claimedVoices, sound)
+}
+
+func listenerPosition() mgl32.Vec2 {
+	return Camera.Position.Add([2]float32{
+		float32(GAME_WIDTH) / Camera.Zoom / 2.0,
+		float32(GAME_HEIGHT) / Camera.Zoom / 2.0,
+	})
+}
+
+func attenuation(pos mgl32.Vec2) float32 {
+	dist := pos.Sub(listenerPosition()).Len()
+	t := mgl32.Clamp((dist-SPATIAL_AUDIO_MIN_DISTANCE)/(SPATIAL_AUDIO_MAX_DISTANCE-SPATIAL_AUDIO_MIN_DISTANCE), 0.0, 1.0)
+	return (1.0 - t) * (1.0 - t)
+}
+
+func (this *AudioMixer) PlayAt(name string, channel AudioChannel, pos mgl32.Vec2) {
+	this.PlaySoundAt(Voice(name, pos), channel, pos)
+}
+
+func (this *AudioMixer) PlaySoundAt(sound gohome.Sound, channel AudioChannel, pos mgl32.Vec2) {
+	if sound == nil {
+		return
+	}
+	volume := this.Volume(channel) * attenuation(pos)
+	if volume == 0.0 {
+		return
+	}
+	sound.SetVolume(volume)
+	sound.Play(false)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/ByteArena/box2d"
 	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
 	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
 )
 
 const (
@@ -21,8 +22,9 @@ type TargetCollect struct {
 	anim gohome.Tweenset
 }
 
-func (this *TargetCollect) Init() {
+func (this *TargetCollect) Init(pos mgl32.Vec2) {
 	this.Sprite2D.Init("TargetCollect")
+	this.Transform.Position = pos
 	this.anim = gohome.SpriteAnimation2D(this.Texture, 4, 1, TARGET_COLLECT_FRAME_TIME)
 	this.anim.SetParent(&this.Sprite2D)
 	this.anim.Start()
@@ -32,11 +34,12 @@ func (this *TargetCollect) Init() {
 	this.Transform.Origin = [2]float32{0.5, 0.5}
 	this.Depth = SPECIAL_DEPTH
 
-	gohome.UpdateMgr.AddObject(this)
-	gohome.UpdateMgr.AddObject(&this.anim)
+	LevelUpdates.AddObject(this)
+	LevelUpdates.AddObject(&this.anim)
 	gohome.RenderMgr.AddObject(this)
+	Entities.Add(this)
 
-	gohome.ResourceMgr.GetSound("TargetCollect").Play(false)
+	Mixer.PlayAt("TargetCollect", CHANNEL_SFX, pos)
 }
 
 func (this *TargetCollect) Update(delta_time float32) {
@@ -46,9 +49,10 @@ func (this *TargetCollect) Update(delta_time float32) {
 }
 
 func (this *TargetCollect) Terminate() {
-	gohome.UpdateMgr.RemoveObject(this)
-	gohome.UpdateMgr.RemoveObject(&this.anim)
+	LevelUpdates.RemoveObject(this)
+	LevelUpdates.RemoveObject(&this.anim)
 	gohome.RenderMgr.RemoveObject(this)
+	Entities.Remove(this)
 }
 
 func (this *Target) Init(texName string) {
@@ -59,12 +63,14 @@ func (this *Target) Init(texName string) {
 	this.anim.Loop = true
 	this.anim.SetParent(&this.Sprite2D)
 	this.anim.Start()
-	gohome.UpdateMgr.AddObject(&this.anim)
+	LevelUpdates.AddObject(&this.anim)
+	Entities.Add(this)
 }
 
 func (this *Target) Terminate() {
 	gohome.RenderMgr.RemoveObject(this)
-	gohome.UpdateMgr.RemoveObject(&this.anim)
+	LevelUpdates.RemoveObject(&this.anim)
+	Entities.Remove(this)
 }
 
 type Sparcles struct {
@@ -73,18 +79,12 @@ type Sparcles struct {
 	body   *box2d.B2Body
 	world  *box2d.B2World
 	weapon *DeleteWeapon
-	paused bool
 }
 
 func (this *Sparcles) Update(delta_time float32) {
-	if this.paused {
-		return
-	}
-
 	if this.anim.Done() {
-		t, ok := this.body.GetUserData().(TerminateObject)
-		if ok {
-			t.Terminate()
+		if block := GetBlock(this.body); block != nil {
+			block.Terminate()
 		}
 		this.world.DestroyBody(this.body)
 		this.Terminate()
@@ -95,8 +95,9 @@ func (this *Sparcles) Update(delta_time float32) {
 
 func (this *Sparcles) Terminate() {
 	gohome.RenderMgr.RemoveObject(this)
-	gohome.UpdateMgr.RemoveObject(this)
-	gohome.UpdateMgr.RemoveObject(&this.anim)
+	LevelUpdates.RemoveObject(this)
+	LevelUpdates.RemoveObject(&this.anim)
+	Entities.Remove(this)
 	for i := 0; i < len(this.weapon.sparcles); i++ {
 		if this.weapon.sparcles[i] == this {
 			this.weapon.sparcles = append(this.weapon.sparcles[:i], this.weapon.sparcles[i+1:]...)
@@ -106,9 +107,8 @@ func (this *Sparcles) Terminate() {
 }
 
 func disappear(body *box2d.B2Body, world *box2d.B2World, wp *DeleteWeapon) *Sparcles {
-	for f := body.GetFixtureList(); f != nil; f = f.GetNext() {
-		var i int = 1
-		f.SetUserData(&i)
+	if block := GetBlock(body); block != nil {
+		block.base().deleting = true
 	}
 
 	var sp Sparcles
@@ -124,8 +124,9 @@ func disappear(body *box2d.B2Body, world *box2d.B2World, wp *DeleteWeapon) *Spar
 	sp.anim.Start()
 	sp.anim.Update(0.0)
 	gohome.RenderMgr.AddObject(&sp)
-	gohome.UpdateMgr.AddObject(&sp.anim)
-	gohome.UpdateMgr.AddObject(&sp)
+	LevelUpdates.AddObject(&sp.anim)
+	LevelUpdates.AddObject(&sp)
+	Entities.Add(&sp)
 
 	return &sp
 }
@@ -135,16 +136,21 @@ type Explosion struct {
 	anim gohome.Tweenset
 }
 
-func (this *Explosion) Init(texName string) {
+func (this *Explosion) Init(texName string, pos mgl32.Vec2) {
 	this.Sprite2D.Init(texName)
+	this.Transform.Position = pos
 	this.Depth = SPECIAL_DEPTH
-	gohome.ResourceMgr.GetSound("Explosion").Play(false)
+	Mixer.PlayAt("Explosion", CHANNEL_SFX, pos)
 }
 
 func (this *Explosion) Update(delta_time float32) {
 	if this.anim.Done() {
-		gohome.RenderMgr.RemoveObject(this)
-		gohome.UpdateMgr.RemoveObject(&this.anim)
-		gohome.UpdateMgr.RemoveObject(this)
+		Entities.Destroy(this)
 	}
 }
+
+func (this *Explosion) Terminate() {
+	gohome.RenderMgr.RemoveObject(this)
+	LevelUpdates.RemoveObject(&this.anim)
+	LevelUpdates.RemoveObject(this)
+}
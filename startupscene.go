@@ -38,11 +38,13 @@ func (this *StartupScene) Init() {
 	gohome.Init2DShaders()
 
 	audio.InitAudio()
-	gohome.AudioMgr.SetVolume(0.5)
+	Mixer.Load()
+	Mixer.Apply()
 
 	LoadResources()
 
 	gohome.UpdateMgr.AddObject(&GlobalUpdate{})
+	MusicMgr.Init()
 
 	gohome.Render.SetBackgroundColor(gohome.Color{52, 101, 255, 255})
 	gohome.RenderMgr.SetCamera2D(&Camera, 0)
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/xml"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+	"os"
+	"path/filepath"
+)
+
+const MAPS_PATH = "assets/maps/"
+
+type tilesetProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type tilesetObjectXML struct {
+	X      float32 `xml:"x,attr"`
+	Y      float32 `xml:"y,attr"`
+	Width  float32 `xml:"width,attr"`
+	Height float32 `xml:"height,attr"`
+}
+
+type tilesetTileXML struct {
+	ID         uint32             `xml:"id,attr"`
+	Properties []tilesetProperty  `xml:"properties>property"`
+	Objects    []tilesetObjectXML `xml:"objectgroup>object"`
+}
+
+type tilesetXML struct {
+	FirstGID uint32           `xml:"firstgid,attr"`
+	Source   string           `xml:"source,attr"`
+	Tiles    []tilesetTileXML `xml:"tile"`
+}
+
+type mapFileXML struct {
+	Tilesets []tilesetXML `xml:"tileset"`
+}
+
+type TileShape struct {
+	Position mgl32.Vec2
+	Size     mgl32.Vec2
+}
+
+type TilesetTile struct {
+	ID         uint32
+	Properties map[string]string
+	Shapes     []TileShape
+}
+
+type Tileset struct {
+	FirstGID uint32
+	Tiles    map[uint32]*TilesetTile
+}
+
+type Tilesets []Tileset
+
+func readXML(path string, v interface{}) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return xml.NewDecoder(file).Decode(v)
+}
+
+func LoadTilesets(mapFile string) (Tilesets, error) {
+	var mf mapFileXML
+	if err := readXML(MAPS_PATH+mapFile, &mf); err != nil {
+		return nil, err
+	}
+
+	var tilesets Tilesets
+	for _, ts := range mf.Tilesets {
+		if ts.Source != "" {
+			var ext tilesetXML
+			if err := readXML(filepath.Join(MAPS_PATH, filepath.Dir(mapFile), ts.Source), &ext); err != nil {
+				return nil, err
+			}
+			ts.Tiles = ext.Tiles
+		}
+		tileset := Tileset{
+			FirstGID: ts.FirstGID,
+			Tiles:    make(map[uint32]*TilesetTile),
+		}
+		for _, t := range ts.Tiles {
+			tile := &TilesetTile{
+				ID:         t.ID,
+				Properties: make(map[string]string),
+			}
+			for _, p := range t.Properties {
+				tile.Properties[p.Name] = p.Value
+			}
+			for _, o := range t.Objects {
+				if o.Width == 0.0 || o.Height == 0.0 {
+					continue
+				}
+				tile.Shapes = append(tile.Shapes, TileShape{
+					Position: [2]float32{o.X, o.Y},
+					Size:     [2]float32{o.Width, o.Height},
+				})
+			}
+			tileset.Tiles[t.ID] = tile
+		}
+		tilesets = append(tilesets, tileset)
+	}
+
+	return tilesets, nil
+}
+
+func (this Tilesets) Tile(gid uint32) *TilesetTile {
+	if gid == 0 {
+		return nil
+	}
+	for i := len(this) - 1; i >= 0; i-- {
+		if gid >= this[i].FirstGID {
+			return this[i].Tiles[gid-this[i].FirstGID]
+		}
+	}
+	return nil
+}
+
+func (this Tilesets) HasProperty(name string) bool {
+	for _, ts := range this {
+		for _, t := range ts.Tiles {
+			if _, ok := t.Properties[name]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
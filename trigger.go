@@ -0,0 +1,254 @@
+package main
+
+import (
+	"github.com/ByteArena/box2d"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
+	"github.com/PucklaMotzer09/mathgl/mgl32"
+)
+
+const (
+	SWITCH_PLATE uint8 = 0
+	SWITCH_SHOOT uint8 = 1
+
+	SWITCH_TILE_OFF uint32 = 48
+	SWITCH_TILE_ON  uint32 = 54
+	DOOR_TILE       uint32 = 16
+	SPIKE_TRAP_TILE uint32 = 84
+)
+
+type Triggerable interface {
+	SetTriggered(on bool)
+}
+
+// Shared by all switches wired to the same target, which stays triggered while any of them is on
+type TriggerInput struct {
+	Target Triggerable
+
+	numOn int
+}
+
+func (this *TriggerInput) SetSwitch(on bool) {
+	wasOn := this.numOn > 0
+	if on {
+		this.numOn++
+	} else {
+		this.numOn--
+	}
+	if isOn := this.numOn > 0; isOn != wasOn {
+		this.Target.SetTriggered(isOn)
+	}
+}
+
+type Switch struct {
+	gohome.Sprite2D
+	Body *box2d.B2Body
+
+	Kind       uint8
+	TileOff    uint32
+	TileOn     uint32
+	TileWidth  int
+	TileHeight int
+	TargetIDs  []uint32
+	Targets    []*TriggerInput
+
+	on         bool
+	pressed    bool
+	contacts   ContactCounter
+	terminated bool
+}
+
+func switchKind(name string) uint8 {
+	if name == "shoot" {
+		return SWITCH_SHOOT
+	}
+	return SWITCH_PLATE
+}
+
+func (this *Switch) Init(pos, size mgl32.Vec2) {
+	this.Sprite2D.Init("Tileset")
+	this.TextureRegion = tileRegion(this.TileOff, this.TileWidth, this.TileHeight)
+	this.Transform.Origin = [2]float32{0.5, 0.5}
+	this.Transform.Size = size
+	this.Transform.Position = pos.Add(size.Mul(0.5))
+	this.Depth = PLATFORM_DEPTH
+
+	bdef := box2d.MakeB2BodyDef()
+	bdef.Type = box2d.B2BodyType.B2_staticBody
+	bdef.Position = physics2d.ToBox2DCoordinates(this.Transform.Position)
+
+	fdef := box2d.MakeB2FixtureDef()
+	fdef.Friction = GROUND_FRICTION
+	fdef.IsSensor = this.Kind == SWITCH_PLATE
+	fdef.Filter = CollisionFilter(SWITCH_CATEGORY)
+	shape := box2d.MakeB2PolygonShape()
+	shape.SetAsBox(physics2d.ScalarToBox2D(size[0]/2.0), physics2d.ScalarToBox2D(size[1]/2.0))
+	fdef.Shape = &shape
+
+	this.Body = PhysicsMgr.World.CreateBody(&bdef)
+	this.Body.CreateFixtureFromDef(&fdef)
+	this.Body.SetUserData(this)
+
+	var mask uint16 = WEAPON_CATEGORIES
+	if this.Kind == SWITCH_PLATE {
+		mask |= PLAYER_CATEGORIES | ENEMY_CATEGORY
+	}
+	this.contacts.Init(ContactFilter{Body: this.Body, Other: mask, IgnoreSensors: true})
+
+	LevelUpdates.AddObject(this)
+	gohome.RenderMgr.AddObject(this)
+	Entities.Add(this)
+	this.terminated = false
+}
+
+func (this *Switch) setOn(on bool) {
+	this.on = on
+	if on {
+		this.TextureRegion = tileRegion(this.TileOn, this.TileWidth, this.TileHeight)
+	} else {
+		this.TextureRegion = tileRegion(this.TileOff, this.TileWidth, this.TileHeight)
+	}
+	for _, t := range this.Targets {
+		t.SetSwitch(on)
+	}
+}
+
+func (this *Switch) Update(delta_time float32) {
+	pressed := this.contacts.Touching()
+	switch this.Kind {
+	case SWITCH_PLATE:
+		if pressed != this.on {
+			this.setOn(pressed)
+		}
+	case SWITCH_SHOOT:
+		if pressed && !this.pressed {
+			this.setOn(!this.on)
+		}
+	}
+	this.pressed = pressed
+}
+
+func (this *Switch) Terminate() {
+	if this.terminated {
+		return
+	}
+
+	this.contacts.Terminate()
+	PhysicsMgr.World.DestroyBody(this.Body)
+	LevelUpdates.RemoveObject(this)
+	gohome.RenderMgr.RemoveObject(this)
+	Entities.Remove(this)
+
+	this.terminated = true
+}
+
+type Door struct {
+	gohome.Sprite2D
+	Body *box2d.B2Body
+	Open bool
+
+	terminated bool
+}
+
+func (this *Door) Init(pos, size mgl32.Vec2, tile uint32, tileWidth, tileHeight int) {
+	this.Sprite2D.InitTexture(createPlatformTexture(size, tile, tileWidth, tileHeight))
+	this.Flip = gohome.FLIP_VERTICAL
+	this.Transform.Origin = [2]float32{0.5, 0.5}
+	this.Transform.Size = size
+	this.Transform.Position = pos.Add(size.Mul(0.5))
+	this.Depth = PLATFORM_DEPTH
+
+	this.Body = createStaticBox(this.Transform.Position, size, GROUND_CATEGORY)
+	this.Body.SetUserData(this)
+
+	gohome.RenderMgr.AddObject(this)
+	Entities.Add(this)
+	this.setOpen(this.Open)
+	this.terminated = false
+}
+
+func (this *Door) setOpen(open bool) {
+	this.Body.SetActive(!open)
+	this.Visible = !open
+}
+
+func (this *Door) SetTriggered(on bool) {
+	this.setOpen(this.Open != on)
+}
+
+func (this *Door) Terminate() {
+	if this.terminated {
+		return
+	}
+
+	PhysicsMgr.World.DestroyBody(this.Body)
+	gohome.RenderMgr.RemoveObject(this)
+	Entities.Remove(this)
+	this.Texture.Terminate()
+
+	this.terminated = true
+}
+
+type SpikeTrap struct {
+	gohome.Sprite2D
+	Body   *box2d.B2Body
+	Active bool
+
+	terminated bool
+}
+
+func (this *SpikeTrap) Init(pos, size mgl32.Vec2, tile uint32, tileWidth, tileHeight int) {
+	this.Sprite2D.InitTexture(createPlatformTexture(size, tile, tileWidth, tileHeight))
+	this.Flip = gohome.FLIP_VERTICAL
+	this.Transform.Origin = [2]float32{0.5, 0.5}
+	this.Transform.Size = size
+	this.Transform.Position = pos.Add(size.Mul(0.5))
+	this.Depth = PLATFORM_DEPTH
+
+	this.Body = createStaticBox(this.Transform.Position, size, SPIKE_CATEGORY)
+	this.Body.SetUserData(this)
+
+	gohome.RenderMgr.AddObject(this)
+	Entities.Add(this)
+	this.setActive(this.Active)
+	this.terminated = false
+}
+
+func (this *SpikeTrap) setActive(active bool) {
+	this.Body.SetActive(active)
+	this.Visible = active
+}
+
+func (this *SpikeTrap) SetTriggered(on bool) {
+	this.setActive(this.Active != on)
+}
+
+func (this *SpikeTrap) Terminate() {
+	if this.terminated {
+		return
+	}
+
+	PhysicsMgr.World.DestroyBody(this.Body)
+	gohome.RenderMgr.RemoveObject(this)
+	Entities.Remove(this)
+	this.Texture.Terminate()
+
+	this.terminated = true
+}
+
+func createStaticBox(pos, size mgl32.Vec2, category uint16) *box2d.B2Body {
+	bdef := box2d.MakeB2BodyDef()
+	bdef.Type = box2d.B2BodyType.B2_staticBody
+	bdef.Position = physics2d.ToBox2DCoordinates(pos)
+
+	fdef := box2d.MakeB2FixtureDef()
+	fdef.Friction = GROUND_FRICTION
+	fdef.Filter = CollisionFilter(category)
+	shape := box2d.MakeB2PolygonShape()
+	shape.SetAsBox(physics2d.ScalarToBox2D(size[0]/2.0), physics2d.ScalarToBox2D(size[1]/2.0))
+	fdef.Shape = &shape
+
+	body := PhysicsMgr.World.CreateBody(&bdef)
+	body.CreateFixtureFromDef(&fdef)
+	return body
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
+)
+
+type Pausable interface {
+	SetPaused(paused bool)
+}
+
+type UpdateGroup struct {
+	Paused    bool
+	TimeScale float32
+
+	objects  []gohome.UpdateObject
+	removed  bool
+	updating bool
+}
+
+func (this *UpdateGroup) Init() {
+	this.Paused = false
+	this.TimeScale = 1.0
+	this.objects = nil
+	this.removed = false
+
+	gohome.UpdateMgr.AddObject(this)
+}
+
+func (this *UpdateGroup) AddObject(obj gohome.UpdateObject) {
+	this.objects = append(this.objects, obj)
+}
+
+func (this *UpdateGroup) RemoveObject(obj gohome.UpdateObject) {
+	for i := 0; i < len(this.objects); i++ {
+		if this.objects[i] == obj {
+			this.objects[i] = nil
+			this.removed = true
+		}
+	}
+	if !this.updating {
+		this.compact()
+	}
+}
+
+func (this *UpdateGroup) compact() {
+	if !this.removed {
+		return
+	}
+	objects := this.objects[:0]
+	for _, obj := range this.objects {
+		if obj != nil {
+			objects = append(objects, obj)
+		}
+	}
+	for i := len(objects); i < len(this.objects); i++ {
+		this.objects[i] = nil
+	}
+	this.objects = objects
+	this.removed = false
+}
+
+func (this *UpdateGroup) SetPaused(paused bool) {
+	this.Paused = paused
+	for _, obj := range this.objects {
+		if p, ok := obj.(Pausable); ok {
+			p.SetPaused(paused)
+		}
+	}
+}
+
+func (this *UpdateGroup) NumObjects() uint32 {
+	return uint32(len(this.objects))
+}
+
+func (this *UpdateGroup) Update(delta_time float32) {
+	if this.Paused {
+		return
+	}
+
+	delta_time *= this.TimeScale
+	this.updating = true
+	for i := 0; i < len(this.objects); i++ {
+		if obj := this.objects[i]; obj != nil {
+			obj.Update(delta_time)
+		}
+	}
+	this.updating = false
+	this.compact()
+}
+
+func (this *UpdateGroup) Terminate() {
+	this.objects = nil
+	this.removed = false
+	gohome.UpdateMgr.RemoveObject(this)
+}
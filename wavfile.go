@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+)
+
+// Reads the samples of an uncompressed 16 bit mono wav file
+func readMonoWAV(path string) ([]int16, uint32, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, 0, errors.New(path + " is not a wav file")
+	}
+	var sampleRate uint32
+	for i := 12; i+8 <= len(data); {
+		id := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		chunk := data[i+8:]
+		if size > len(chunk) {
+			size = len(chunk)
+		}
+		chunk = chunk[:size]
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, 0, errors.New(path + " has a broken format chunk")
+			}
+			format := binary.LittleEndian.Uint16(chunk[0:])
+			channels := binary.LittleEndian.Uint16(chunk[2:])
+			bits := binary.LittleEndian.Uint16(chunk[14:])
+			if format != 1 || channels != 1 || bits != 16 {
+				return nil, 0, errors.New(path + " is not 16 bit mono PCM")
+			}
+			sampleRate = binary.LittleEndian.Uint32(chunk[4:])
+		case "data":
+			if sampleRate == 0 {
+				return nil, 0, errors.New(path + " has no format chunk before its data")
+			}
+			samples := make([]int16, size/2)
+			for j := range samples {
+				samples[j] = int16(binary.LittleEndian.Uint16(chunk[j*2:]))
+			}
+			return samples, sampleRate, nil
+		}
+		i += 8 + size + size&1
+	}
+	return nil, 0, errors.New(path + " has no data")
+}
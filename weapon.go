@@ -2,7 +2,6 @@ package main
 
 import (
 	"github.com/PucklaMotzer09/GoHomeEngine/src/gohome"
-	"github.com/PucklaMotzer09/GoHomeEngine/src/physics2d"
 	"github.com/PucklaMotzer09/mathgl/mgl32"
 )
 
@@ -14,19 +13,9 @@ type Weapon interface {
 	GetInventoryTexture() gohome.Texture
 	Terminate()
 	GetAmmo() uint32
-	Pause()
-	Resume()
-}
-
-type WeaponBlock struct {
-	Sprite    *gohome.Sprite2D
-	Connector *physics2d.PhysicsConnector2D
-	paused    bool
-}
+	SetAmmo(ammo uint32)
 
-func (this *WeaponBlock) Terminate() {
-	gohome.RenderMgr.RemoveObject(this.Sprite)
-	this.Connector.Terminate()
+	removeBlock(block *WeaponBlock)
 }
 
 type NilWeapon struct {
@@ -35,8 +24,7 @@ type NilWeapon struct {
 	Player *Player
 	tex    gohome.RenderTexture
 	Ammo   uint32
-	blocks []WeaponBlock
-	paused bool
+	blocks []Block
 }
 
 const (
@@ -67,15 +55,17 @@ func (this *NilWeapon) OnDie() {
 }
 
 func (this *NilWeapon) Use(target mgl32.Vec2, energy float32) {
-	var shape2d gohome.Shape2D
-	shape2d.Init()
+	var aimLine AimLine
+	aimLine.Init()
 	var line gohome.Line2D
 	line[0].Make(this.Player.Transform.Position, gohome.Color{uint8(255.0 * energy), 0, 0, 255})
 	line[1].Make(target, gohome.Color{uint8(255.0 * energy), 0, 0, 255})
-	shape2d.AddLines([]gohome.Line2D{line})
-	shape2d.Load()
-	shape2d.SetDrawMode(gohome.DRAW_MODE_LINES)
-	gohome.RenderMgr.AddObject(&shape2d)
+	aimLine.AddLines([]gohome.Line2D{line})
+	aimLine.Load()
+	aimLine.SetDrawMode(gohome.DRAW_MODE_LINES)
+	aimLine.Depth = MAP_DEPTH
+	gohome.RenderMgr.AddObject(&aimLine)
+	Entities.Add(&aimLine)
 
 	this.Ammo--
 }
@@ -86,8 +76,8 @@ func (this *NilWeapon) GetInventoryTexture() gohome.Texture {
 
 func (this *NilWeapon) Terminate() {
 	gohome.RenderMgr.RemoveObject(this)
-	for _, block := range this.blocks {
-		block.Terminate()
+	for len(this.blocks) > 0 {
+		this.blocks[0].Terminate()
 	}
 }
 
@@ -99,10 +89,15 @@ func (this *NilWeapon) GetAmmo() uint32 {
 	return this.Ammo
 }
 
-func (this *NilWeapon) Pause() {
-	this.paused = true
+func (this *NilWeapon) SetAmmo(ammo uint32) {
+	this.Ammo = ammo
 }
 
-func (this *NilWeapon) Resume() {
-	this.paused = false
+type AimLine struct {
+	gohome.Shape2D
+}
+
+func (this *AimLine) Terminate() {
+	gohome.RenderMgr.RemoveObject(this)
+	this.Shape2D.Terminate()
 }